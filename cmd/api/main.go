@@ -7,13 +7,27 @@ import (
 	netHttp "net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/bvaledev/database-backup-management-be/internal/application/audit"
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
 	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	"github.com/bvaledev/database-backup-management-be/internal/application/notification"
+	authContract "github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	auditRepository "github.com/bvaledev/database-backup-management-be/internal/infra/audit/db/repository"
+	auditHttp "github.com/bvaledev/database-backup-management-be/internal/infra/audit/handler/http"
+	authRepository "github.com/bvaledev/database-backup-management-be/internal/infra/auth/db/repository"
+	authHttp "github.com/bvaledev/database-backup-management-be/internal/infra/auth/handler/http"
 	"github.com/bvaledev/database-backup-management-be/internal/infra/backup/db"
 	"github.com/bvaledev/database-backup-management-be/internal/infra/backup/db/repository"
 	"github.com/bvaledev/database-backup-management-be/internal/infra/backup/handler/http"
+	notificationRepository "github.com/bvaledev/database-backup-management-be/internal/infra/notification/db/repository"
+	notificationHttp "github.com/bvaledev/database-backup-management-be/internal/infra/notification/handler/http"
+	notificationMail "github.com/bvaledev/database-backup-management-be/internal/infra/notification/mail"
 
 	"github.com/bvaledev/database-backup-management-be/internal/pkg/encryption"
 	"github.com/go-chi/chi"
@@ -47,19 +61,122 @@ func main() {
 
 	backupRepo := repository.NewBackupRepository(dbConn.DB)
 	datasourceRepo := repository.NewDatasourceRepository(dbConn.DB)
+	backupProfileRepo := repository.NewBackupProfileRepository(dbConn.DB)
+	scheduleRepo := repository.NewScheduleRepository(dbConn.DB)
+	blackoutWindowRepo := repository.NewBlackoutWindowRepository(dbConn.DB)
+	restoreRequestRepo := repository.NewRestoreRequestRepository(dbConn.DB)
+	restoreRepo := repository.NewRestoreRepository(dbConn.DB)
+	backupContentsRepo := repository.NewBackupContentsRepository(dbConn.DB)
+	temporaryDatabaseRepo := repository.NewTemporaryDatabaseRepository(dbConn.DB)
+	walSegmentRepo := repository.NewWalSegmentRepository(dbConn.DB)
+	webhookRepo := notificationRepository.NewWebhookRepository(dbConn.DB)
+	webhookDeliveryRepo := notificationRepository.NewWebhookDeliveryRepository(dbConn.DB)
+	emailRecipientRepo := notificationRepository.NewEmailRecipientRepository(dbConn.DB)
+	chatChannelRepo := notificationRepository.NewChatChannelRepository(dbConn.DB)
+	apiKeyRepo := authRepository.NewApiKeyRepository(dbConn.DB)
+	userRepo := authRepository.NewUserRepository(dbConn.DB)
+	roleRepo := authRepository.NewRoleRepository(dbConn.DB)
+	auditRepo := auditRepository.NewAuditRepository(dbConn.DB)
 
-	postgresBackupService := backup.NewPostgresBackupService()
-	PostgresBackupCommand := backup.NewPostgresBackupCommand(postgresBackupService, backupRepo)
+	auditRecorder := audit.NewRecorder(auditRepo)
 
-	backupController := http.NewBackupController(backupRepo, datasourceRepo, postgresBackupService, PostgresBackupCommand)
-	datasourceController := http.NewDatasourceController(datasourceRepo)
+	webhookNotifier := notification.NewWebhookNotifier(webhookRepo, webhookDeliveryRepo)
+	chatNotifier := notification.NewChatNotifier(chatChannelRepo, backupRepo, os.Getenv("FRONTEND_URL"))
+	notifiers := []notificationContract.INotifier{webhookNotifier, chatNotifier}
 
-	jobManager := backup.NewJobManager(datasourceRepo, PostgresBackupCommand)
+	var digestSender notificationContract.IDigestSender
+	if smtpConfig, ok := notificationMail.SMTPConfigFromEnv(); ok {
+		emailNotifier := notification.NewEmailNotifier(notificationMail.NewSMTPMailer(smtpConfig), emailRecipientRepo, datasourceRepo, backupRepo)
+		digestCron := os.Getenv("SMTP_DIGEST_CRON")
+		if digestCron == "" {
+			digestCron = "0 0 8 * * *"
+		}
+		if err := emailNotifier.StartDigest(digestCron); err != nil {
+			log.Fatalf("Erro ao agendar o resumo diário por e-mail: %s", err)
+		}
+		defer emailNotifier.Stop()
+		notifiers = append(notifiers, emailNotifier)
+		digestSender = emailNotifier
+	}
+	notifier := notification.NewDispatcher(notifiers...)
+
+	pgClients := backup.PgClientsFromEnv()
+	postgresBackupService := backup.NewPostgresBackupService(pgClients)
+	physicalBackupConfig := backup.PhysicalBackupConfigFromEnv()
+	physicalBackupService := backup.NewPostgresPhysicalBackupService(physicalBackupConfig, pgClients)
+	PostgresBackupCommand := backup.NewPostgresBackupCommand(postgresBackupService, physicalBackupService, backupRepo, backupProfileRepo, scheduleRepo, notifier)
+
+	restoreRunner := backup.NewRestoreRunner(backupRepo, restoreRepo, datasourceRepo, temporaryDatabaseRepo, walSegmentRepo, postgresBackupService, physicalBackupService, PostgresBackupCommand, notifier, backup.NewDatabaseConfigFromEnv())
+	temporaryDatabaseCleaner := backup.NewTemporaryDatabaseCleaner(temporaryDatabaseRepo, datasourceRepo, postgresBackupService)
+
+	backupController := http.NewBackupController(backupRepo, datasourceRepo, restoreRequestRepo, PostgresBackupCommand, restoreRunner, notifier, auditRecorder)
+	restoreRequestController := http.NewRestoreRequestController(restoreRequestRepo, backupRepo, datasourceRepo, restoreRunner, notifier, auditRecorder)
+	restoresController := http.NewRestoresController(restoreRepo, datasourceRepo)
+	backupArtifactsController := http.NewBackupArtifactsController(backupRepo, datasourceRepo, backup.NewBackupArtifacts(backupRepo), backup.NewDownloadSignerFromEnv(), auditRecorder)
+	backupContentsController := http.NewBackupContentsController(backupRepo, datasourceRepo, backupContentsRepo, postgresBackupService, auditRecorder)
+	walArchiveController := http.NewWalArchiveController(datasourceRepo, walSegmentRepo, backup.NewWalArchive(walSegmentRepo, physicalBackupConfig))
+	temporaryDatabaseController := http.NewTemporaryDatabaseController(temporaryDatabaseRepo, datasourceRepo, temporaryDatabaseCleaner, auditRecorder)
+	datasourceController := http.NewDatasourceController(datasourceRepo, backupProfileRepo, auditRecorder)
+	backupProfileController := http.NewBackupProfileController(backupProfileRepo, datasourceRepo, auditRecorder)
+	scheduleController := http.NewScheduleController(scheduleRepo, datasourceRepo, auditRecorder)
+	blackoutWindowController := http.NewBlackoutWindowController(blackoutWindowRepo, datasourceRepo, auditRecorder)
+	discoveryController := http.NewDiscoveryController(datasourceRepo, backupProfileRepo, postgresBackupService, auditRecorder)
+	webhookController := notificationHttp.NewWebhookController(webhookRepo, webhookDeliveryRepo, webhookNotifier)
+	emailRecipientController := notificationHttp.NewEmailRecipientController(emailRecipientRepo, digestSender)
+	chatChannelController := notificationHttp.NewChatChannelController(chatChannelRepo, chatNotifier)
+	apiKeyController := authHttp.NewApiKeyController(apiKeyRepo, auditRecorder)
+	userController := authHttp.NewUserController(userRepo, roleRepo, auditRecorder)
+	roleController := authHttp.NewRoleController(roleRepo, auditRecorder)
+	meController := authHttp.NewMeController()
+	auditController := auditHttp.NewAuditController(auditRepo)
+
+	bootstrapApiKey := os.Getenv("BOOTSTRAP_API_KEY")
+	if bootstrapApiKey == "" {
+		log.Println("BOOTSTRAP_API_KEY não definida: apenas chaves de API cadastradas terão acesso à API.")
+	}
+	apiKeyAuthenticator := auth.NewApiKeyAuthenticator(apiKeyRepo, userRepo, bootstrapApiKey)
+
+	var oidcAuthenticator authContract.IAuthenticator
+	oidcConfig, ok, err := auth.OIDCConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Erro na configuração OIDC: %s", err)
+	}
+	if ok {
+		oidcAuthenticator = auth.NewOIDCAuthenticator(oidcConfig, userRepo, roleRepo)
+		log.Printf("Autenticação OIDC habilitada para o emissor %s", oidcConfig.Issuer)
+	}
+	authenticator := auth.NewBearerAuthenticator(apiKeyAuthenticator, oidcAuthenticator)
+
+	jobManager := backup.NewJobManager(datasourceRepo, backupProfileRepo, scheduleRepo, blackoutWindowRepo, PostgresBackupCommand)
 	jobManager.Start()
 	defer jobManager.Stop()
 
+	temporaryDatabaseCleaner.Start()
+	defer temporaryDatabaseCleaner.Stop()
+
 	appPort := os.Getenv("PORT")
-	server := &netHttp.Server{Addr: fmt.Sprintf("0.0.0.0:%s", appPort), Handler: appRouters(datasourceController, backupController)}
+	server := &netHttp.Server{Addr: fmt.Sprintf("0.0.0.0:%s", appPort), Handler: appRouters(appControllers{
+		datasource:      datasourceController,
+		discovery:       discoveryController,
+		backupProfile:   backupProfileController,
+		schedule:        scheduleController,
+		blackoutWindow:  blackoutWindowController,
+		backup:          backupController,
+		backupContents:  backupContentsController,
+		backupArtifacts: backupArtifactsController,
+		walArchive:      walArchiveController,
+		restoreRequest:  restoreRequestController,
+		restore:         restoresController,
+		temporaryDb:     temporaryDatabaseController,
+		webhook:         webhookController,
+		emailRecipient:  emailRecipientController,
+		chatChannel:     chatChannelController,
+		apiKey:          apiKeyController,
+		user:            userController,
+		role:            roleController,
+		me:              meController,
+		audit:           auditController,
+	}, authenticator)}
 	serverCtx, serverStopCtx := context.WithCancel(context.Background())
 
 	// Listen for syscall signals for process to interrupt/quit
@@ -95,33 +212,163 @@ func main() {
 	<-serverCtx.Done()
 }
 
-func appRouters(dsc *http.DatasourceController, bkp *http.BackupsController) netHttp.Handler {
+type appControllers struct {
+	datasource      *http.DatasourceController
+	discovery       *http.DiscoveryController
+	backupProfile   *http.BackupProfileController
+	schedule        *http.ScheduleController
+	blackoutWindow  *http.BlackoutWindowController
+	backup          *http.BackupsController
+	backupContents  *http.BackupContentsController
+	backupArtifacts *http.BackupArtifactsController
+	walArchive      *http.WalArchiveController
+	restoreRequest  *http.RestoreRequestController
+	restore         *http.RestoresController
+	temporaryDb     *http.TemporaryDatabaseController
+	webhook         *notificationHttp.WebhookController
+	emailRecipient  *notificationHttp.EmailRecipientController
+	chatChannel     *notificationHttp.ChatChannelController
+	apiKey          *authHttp.ApiKeyController
+	user            *authHttp.UserController
+	role            *authHttp.RoleController
+	me              *authHttp.MeController
+	audit           *auditHttp.AuditController
+}
+
+func appRouters(c appControllers, authenticator authContract.IAuthenticator) netHttp.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(auditHttp.RequestInfo)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 	r.Use(middleware.AllowContentEncoding("deflate", "gzip"))
 	r.Use(middleware.CleanPath)
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins: []string{"https://*", "http://*"},
+		AllowedOrigins: allowedOrigins(),
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Range", authHttp.ApiKeyHeader},
+		ExposedHeaders: []string{"Content-Disposition", "Content-Range", "ETag", "Digest", "X-Checksum-SHA256"},
 	}))
 
-	r.Get("/v1/datasources", dsc.List)
-	r.Get("/v1/datasources/{id}", dsc.Get)
-	r.Post("/v1/datasources", dsc.Create)
-	r.Put("/v1/datasources/{id}", dsc.Update)
-	r.Delete("/v1/datasources/{id}", dsc.Delete)
+	can := authHttp.RequirePermission
+	admin := can(authEntity.PermAdmin)
+
+	// Download aceito também por URL assinada, sem credenciais.
+	r.With(c.backupArtifacts.AllowSignedURL(authHttp.Authenticate(authenticator), can(authEntity.PermBackupRead))).Get("/v1/backups/{id}/download", c.backupArtifacts.Download)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authHttp.Authenticate(authenticator))
 
-	r.Get("/v1/backups", bkp.List)
-	r.Get("/v1/backups/{id}", bkp.Get)
-	r.Post("/v1/backups", bkp.CreateBackup)
-	r.Post("/v1/backups/{id}/restore-backup", bkp.RestoreBackup)
-	r.Delete("/v1/backups/{id}", bkp.Delete)
+		r.Get("/v1/me", c.me.Get)
+
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/datasources", c.datasource.List)
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/datasources/{id}", c.datasource.Get)
+		r.With(can(authEntity.PermDatasourceWrite)).Post("/v1/datasources", c.datasource.Create)
+		r.With(can(authEntity.PermDatasourceWrite)).Put("/v1/datasources/{id}", c.datasource.Update)
+		r.With(can(authEntity.PermDatasourceWrite)).Delete("/v1/datasources/{id}", c.datasource.Delete)
+		r.With(can(authEntity.PermDatasourceWrite)).Post("/v1/discovery/databases", c.discovery.Databases)
+		r.With(can(authEntity.PermDatasourceWrite)).Post("/v1/discovery/datasources", c.discovery.Register)
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/datasources/{id}/schedules", c.schedule.List)
+		r.With(can(authEntity.PermDatasourceWrite)).Post("/v1/datasources/{id}/schedules", c.schedule.Create)
+		r.With(can(authEntity.PermDatasourceWrite)).Put("/v1/datasources/{id}/schedules/{scheduleId}", c.schedule.Update)
+		r.With(can(authEntity.PermDatasourceWrite)).Delete("/v1/datasources/{id}/schedules/{scheduleId}", c.schedule.Delete)
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/datasources/{id}/blackout-windows", c.blackoutWindow.List)
+		r.With(can(authEntity.PermDatasourceWrite)).Post("/v1/datasources/{id}/blackout-windows", c.blackoutWindow.Create)
+		r.With(can(authEntity.PermDatasourceWrite)).Put("/v1/datasources/{id}/blackout-windows/{windowId}", c.blackoutWindow.Update)
+		r.With(can(authEntity.PermDatasourceWrite)).Delete("/v1/datasources/{id}/blackout-windows/{windowId}", c.blackoutWindow.Delete)
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/backup-profiles", c.backupProfile.List)
+		r.With(can(authEntity.PermDatasourceRead)).Get("/v1/backup-profiles/{id}", c.backupProfile.Get)
+		r.With(admin).Post("/v1/backup-profiles", c.backupProfile.Create)
+		r.With(admin).Put("/v1/backup-profiles/{id}", c.backupProfile.Update)
+		r.With(admin).Delete("/v1/backup-profiles/{id}", c.backupProfile.Delete)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/datasources/{id}/wal", c.walArchive.List)
+		r.With(can(authEntity.PermBackupCreate)).Put("/v1/datasources/{id}/wal/{file}", c.walArchive.Archive)
+
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/backups", c.backup.List)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/backups/{id}", c.backup.Get)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/backups/{id}/contents", c.backupContents.Contents)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/backups/{id}/tables/{table}/export", c.backupContents.ExportTable)
+		r.With(can(authEntity.PermBackupRead)).Post("/v1/backups/{id}/download-url", c.backupArtifacts.DownloadURL)
+		r.With(can(authEntity.PermBackupCreate)).Post("/v1/backups", c.backup.CreateBackup)
+		r.With(can(authEntity.PermBackupCreate)).Post("/v1/backups/import", c.backupArtifacts.Import)
+		r.With(can(authEntity.PermBackupRestore)).Post("/v1/backups/{id}/restore-backup", c.backup.RestoreBackup)
+		r.With(can(authEntity.PermBackupRestore)).Post("/v1/backups/{id}/rollback", c.backup.Rollback)
+		r.With(can(authEntity.PermBackupDelete)).Delete("/v1/backups/{id}", c.backup.Delete)
+
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/restores", c.restore.List)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/restores/{id}", c.restore.Get)
+
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/restore-requests", c.restoreRequest.List)
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/restore-requests/{id}", c.restoreRequest.Get)
+		r.With(can(authEntity.PermBackupRestore)).Post("/v1/restore-requests/{id}/approve", c.restoreRequest.Approve)
+		r.With(can(authEntity.PermBackupRestore)).Post("/v1/restore-requests/{id}/reject", c.restoreRequest.Reject)
+
+		r.With(can(authEntity.PermBackupRead)).Get("/v1/temporary-databases", c.temporaryDb.List)
+		r.With(can(authEntity.PermBackupRestore)).Delete("/v1/temporary-databases/{id}", c.temporaryDb.Delete)
+
+		r.With(admin).Get("/v1/webhooks", c.webhook.List)
+		r.With(admin).Get("/v1/webhooks/{id}", c.webhook.Get)
+		r.With(admin).Post("/v1/webhooks", c.webhook.Create)
+		r.With(admin).Put("/v1/webhooks/{id}", c.webhook.Update)
+		r.With(admin).Delete("/v1/webhooks/{id}", c.webhook.Delete)
+		r.With(admin).Post("/v1/webhooks/{id}/test", c.webhook.Test)
+		r.With(admin).Get("/v1/webhooks/{id}/deliveries", c.webhook.Deliveries)
+
+		r.With(admin).Get("/v1/email-recipients", c.emailRecipient.List)
+		r.With(admin).Get("/v1/email-recipients/{id}", c.emailRecipient.Get)
+		r.With(admin).Post("/v1/email-recipients", c.emailRecipient.Create)
+		r.With(admin).Put("/v1/email-recipients/{id}", c.emailRecipient.Update)
+		r.With(admin).Delete("/v1/email-recipients/{id}", c.emailRecipient.Delete)
+		r.With(admin).Post("/v1/email-recipients/digest", c.emailRecipient.SendDigest)
+
+		r.With(admin).Get("/v1/chat-channels", c.chatChannel.List)
+		r.With(admin).Get("/v1/chat-channels/{id}", c.chatChannel.Get)
+		r.With(admin).Post("/v1/chat-channels", c.chatChannel.Create)
+		r.With(admin).Put("/v1/chat-channels/{id}", c.chatChannel.Update)
+		r.With(admin).Delete("/v1/chat-channels/{id}", c.chatChannel.Delete)
+		r.With(admin).Post("/v1/chat-channels/{id}/test", c.chatChannel.Test)
+
+		r.With(admin).Get("/v1/api-keys", c.apiKey.List)
+		r.With(admin).Post("/v1/api-keys", c.apiKey.Create)
+		r.With(admin).Delete("/v1/api-keys/{id}", c.apiKey.Revoke)
+
+		r.With(admin).Get("/v1/users", c.user.List)
+		r.With(admin).Get("/v1/users/{id}", c.user.Get)
+		r.With(admin).Post("/v1/users", c.user.Create)
+		r.With(admin).Put("/v1/users/{id}", c.user.Update)
+		r.With(admin).Delete("/v1/users/{id}", c.user.Delete)
+		r.With(admin).Get("/v1/users/{id}/grants", c.user.ListGrants)
+		r.With(admin).Post("/v1/users/{id}/grants", c.user.CreateGrant)
+		r.With(admin).Delete("/v1/users/{id}/grants/{grantId}", c.user.DeleteGrant)
+
+		r.With(admin).Get("/v1/roles", c.role.List)
+		r.With(admin).Get("/v1/roles/{id}", c.role.Get)
+		r.With(admin).Post("/v1/roles", c.role.Create)
+		r.With(admin).Put("/v1/roles/{id}", c.role.Update)
+		r.With(admin).Delete("/v1/roles/{id}", c.role.Delete)
+
+		r.With(admin).Get("/v1/audit", c.audit.List)
+	})
 
 	return r
 }
+
+// allowedOrigins retorna as origens liberadas no CORS a partir de CORS_ALLOWED_ORIGINS (separadas por vírgula),
+// utilizando FRONTEND_URL como padrão.
+func allowedOrigins() []string {
+	origins := os.Getenv("CORS_ALLOWED_ORIGINS")
+	if origins == "" {
+		origins = os.Getenv("FRONTEND_URL")
+	}
+
+	allowed := make([]string, 0)
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed = append(allowed, origin)
+		}
+	}
+	return allowed
+}
@@ -1,20 +1,31 @@
 package main
 
 import (
+	"database/sql"
+	"flag"
 	"log"
 	"os"
+	"time"
 
 	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	"github.com/bvaledev/database-backup-management-be/internal/application/notification"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/infra/backup/db"
 	"github.com/bvaledev/database-backup-management-be/internal/infra/backup/db/repository"
+	notificationRepository "github.com/bvaledev/database-backup-management-be/internal/infra/notification/db/repository"
+	notificationMail "github.com/bvaledev/database-backup-management-be/internal/infra/notification/mail"
 	"github.com/bvaledev/database-backup-management-be/internal/pkg/encryption"
 	"github.com/joho/godotenv"
 )
 
 var postgresBackupService contract.IBackupService
+var physicalBackupService contract.IPhysicalBackupService
 var backupRepo contract.IBackupRepository
+var backupProfileRepo contract.IBackupProfileRepository
+var scheduleRepo contract.IScheduleRepository
+var notifier *notification.Dispatcher
 
 func init() {
 	log.Println("Carregando variáveis de ambiente do .env")
@@ -40,8 +51,21 @@ func main() {
 	}
 
 	backupRepo = repository.NewBackupRepository(dbConn.DB)
-	postgresBackupService = backup.NewPostgresBackupService()
+	backupProfileRepo = repository.NewBackupProfileRepository(dbConn.DB)
+	scheduleRepo = repository.NewScheduleRepository(dbConn.DB)
+	notifier = newNotifier(dbConn.DB)
+	pgClients := backup.PgClientsFromEnv()
+	postgresBackupService = backup.NewPostgresBackupService(pgClients)
+	physicalBackupService = backup.NewPostgresPhysicalBackupService(backup.PhysicalBackupConfigFromEnv(), pgClients)
 
+	if len(os.Args) > 1 && os.Args[1] == "export-table" {
+		exportTable(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "restore-data-directory" {
+		restoreDataDirectory(os.Args[2:])
+		return
+	}
 	createBackup()
 }
 
@@ -56,11 +80,27 @@ func createBackup() {
 		SSLMode:  "disable",
 	}
 
-	PostgresBackupCommand := backup.NewPostgresBackupCommand(postgresBackupService, backupRepo)
+	PostgresBackupCommand := backup.NewPostgresBackupCommand(postgresBackupService, physicalBackupService, backupRepo, backupProfileRepo, scheduleRepo, notifier)
 
 	backaupCommand := PostgresBackupCommand.Command(*ds, entity.BackupManual)
 
 	backaupCommand()
+	// A CLI encerra logo após o backup: as notificações em segundo plano precisam terminar antes.
+	notifier.Wait()
+}
+
+// newNotifier configura os mesmos canais de notificação da API: webhooks, chats e, com SMTP configurado, alertas
+// por e-mail. O resumo diário continua sendo enviado apenas pela API.
+func newNotifier(conn *sql.DB) *notification.Dispatcher {
+	backupRepo := repository.NewBackupRepository(conn)
+	notifiers := []notificationContract.INotifier{
+		notification.NewWebhookNotifier(notificationRepository.NewWebhookRepository(conn), notificationRepository.NewWebhookDeliveryRepository(conn)),
+		notification.NewChatNotifier(notificationRepository.NewChatChannelRepository(conn), backupRepo, os.Getenv("FRONTEND_URL")),
+	}
+	if smtpConfig, ok := notificationMail.SMTPConfigFromEnv(); ok {
+		notifiers = append(notifiers, notification.NewEmailNotifier(notificationMail.NewSMTPMailer(smtpConfig), notificationRepository.NewEmailRecipientRepository(conn), repository.NewDatasourceRepository(conn), backupRepo))
+	}
+	return notification.NewDispatcher(notifiers...)
 }
 
 func restore() {
@@ -73,9 +113,107 @@ func restore() {
 		Password: "root",
 		SSLMode:  "disable",
 	}
-	output, err := postgresBackupService.Restore(*datasource, "./backups/defaultdb-1743828420.sql.gz")
+	output, err := postgresBackupService.Restore(*datasource, "./backups/defaultdb-1743828420.sql.gz", "", entity.RestoreScope{})
 	if err != nil {
 		panic(err)
 	}
 	log.Println("Restore output:", output)
 }
+
+// exportTable extrai os dados de uma tabela de um backup para a saída padrão (ou -o), sem restaurá-lo.
+//
+//	go run ./cmd/cli export-table -backup <id> -table public.users -format ndjson -o users.ndjson
+func exportTable(args []string) {
+	flags := flag.NewFlagSet("export-table", flag.ExitOnError)
+	backupId := flags.String("backup", "", "id do backup")
+	table := flags.String("table", "", "tabela, opcionalmente com o schema (schema.tabela)")
+	format := flags.String("format", "csv", "formato de saída: csv ou ndjson")
+	output := flags.String("o", "", "arquivo de saída (padrão: saída padrão)")
+	flags.Parse(args)
+
+	if *backupId == "" || *table == "" {
+		log.Fatal("informe -backup e -table")
+	}
+	if *format != "csv" && *format != "ndjson" {
+		log.Fatal("formato inválido, use csv ou ndjson")
+	}
+
+	bkp, err := backupRepo.GetBackup(*backupId)
+	if err != nil {
+		log.Fatalf("backup não encontrado: %v", err)
+	}
+	reader, err := postgresBackupService.OpenTableData(bkp.FilePath, *table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer reader.Close()
+
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer out.Close()
+	}
+
+	if *format == "ndjson" {
+		err = reader.WriteNDJSON(out)
+	} else {
+		err = reader.WriteCSV(out)
+	}
+	if err != nil {
+		log.Fatalf("erro ao exportar a tabela: %v", err)
+	}
+}
+
+// restoreDataDirectory extrai um backup físico em um novo diretório de dados (em PHYSICAL_RESTORE_DIR),
+// que pode ser iniciado com os binários locais do PostgreSQL. Com -target-time ou -target-lsn, o diretório
+// é configurado para recuperar até o ponto informado com os WAL arquivados (WAL_ARCHIVE_DIR).
+//
+//	go run ./cmd/cli restore-data-directory -backup <id> -name teste -target-time 2025-04-05T10:30:00Z
+//	pg_ctl -D ./restores/teste -o "-p 5433" start
+func restoreDataDirectory(args []string) {
+	flags := flag.NewFlagSet("restore-data-directory", flag.ExitOnError)
+	backupId := flags.String("backup", "", "id do backup físico")
+	name := flags.String("name", "", "nome do diretório de dados")
+	targetTime := flags.String("target-time", "", "instante da recuperação (RFC 3339)")
+	targetLSN := flags.String("target-lsn", "", "LSN da recuperação (ex: 0/16B3748)")
+	flags.Parse(args)
+
+	if *backupId == "" || *name == "" {
+		log.Fatal("informe -backup e -name")
+	}
+	options := entity.RestoreOptions{Mode: entity.RestoreDataDirectory, DataDirectory: *name}
+	if *targetTime != "" || *targetLSN != "" {
+		options.RecoveryTarget = &entity.RecoveryTarget{LSN: *targetLSN}
+		if *targetTime != "" {
+			at, err := time.Parse(time.RFC3339, *targetTime)
+			if err != nil {
+				log.Fatalf("-target-time inválido: %v", err)
+			}
+			options.RecoveryTarget.Time = &at
+		}
+	}
+	if err := options.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	bkp, err := backupRepo.GetBackup(*backupId)
+	if err != nil {
+		log.Fatalf("backup não encontrado: %v", err)
+	}
+	dataDir, output, err := physicalBackupService.RestoreDataDirectory(bkp.FilePath, *name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(output)
+
+	if options.RecoveryTarget != nil {
+		recovery, err := physicalBackupService.ConfigureRecovery(dataDir, bkp.DatasourceId, *options.RecoveryTarget)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(recovery)
+	}
+}
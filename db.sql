@@ -1,5 +1,22 @@
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+CREATE TABLE backup_profiles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    cron_expr TEXT NOT NULL,
+    time_zone VARCHAR NOT NULL DEFAULT '',
+    backup_format VARCHAR NOT NULL DEFAULT 'plain' CHECK (backup_format IN ('plain', 'custom', 'tar', 'directory', 'physical')),
+    backup_jobs INTEGER NOT NULL DEFAULT 1 CHECK (backup_jobs > 0),
+    compression_level INTEGER NOT NULL DEFAULT 0 CHECK (compression_level BETWEEN 0 AND 9),
+    dump_filter JSONB NOT NULL DEFAULT '{}',
+    schema_only BOOLEAN NOT NULL DEFAULT false,
+    retention_keep_last INTEGER NOT NULL DEFAULT 0 CHECK (retention_keep_last >= 0),
+    retention_max_age_days INTEGER NOT NULL DEFAULT 0 CHECK (retention_max_age_days >= 0),
+    timeout_minutes INTEGER NOT NULL DEFAULT 0 CHECK (timeout_minutes >= 0),
+    created_at TIMESTAMP NOT NULL
+);
+
 CREATE TABLE datasources (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     database VARCHAR NOT NULL,
@@ -10,18 +27,257 @@ CREATE TABLE datasources (
     password VARCHAR NOT NULL,
     cron_expr TEXT NOT NULL,
     description TEXT,
-    enabled BOOLEAN NOT NULL
+    enabled BOOLEAN NOT NULL,
+    time_zone VARCHAR NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    protected BOOLEAN NOT NULL DEFAULT false,
+    backup_format VARCHAR NOT NULL DEFAULT 'plain' CHECK (backup_format IN ('plain', 'custom', 'tar', 'directory', 'physical')),
+    backup_jobs INTEGER NOT NULL DEFAULT 1 CHECK (backup_jobs > 0),
+    dump_filter JSONB NOT NULL DEFAULT '{}',
+    include_globals BOOLEAN NOT NULL DEFAULT false,
+    profile_id UUID REFERENCES backup_profiles(id) ON DELETE RESTRICT
+);
+
+CREATE TABLE schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    cron_expr TEXT NOT NULL,
+    time_zone VARCHAR NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL,
+    backup_format VARCHAR NOT NULL DEFAULT 'plain' CHECK (backup_format IN ('plain', 'custom', 'tar', 'directory', 'physical')),
+    backup_jobs INTEGER NOT NULL DEFAULT 1 CHECK (backup_jobs > 0),
+    compression_level INTEGER NOT NULL DEFAULT 0 CHECK (compression_level BETWEEN 0 AND 9),
+    dump_filter JSONB NOT NULL DEFAULT '{}',
+    schema_only BOOLEAN NOT NULL DEFAULT false,
+    retention_keep_last INTEGER NOT NULL DEFAULT 0 CHECK (retention_keep_last >= 0),
+    retention_max_age_days INTEGER NOT NULL DEFAULT 0 CHECK (retention_max_age_days >= 0),
+    timeout_minutes INTEGER NOT NULL DEFAULT 0 CHECK (timeout_minutes >= 0),
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (datasource_id, name)
+);
+
+CREATE TABLE blackout_windows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    cron_expr TEXT NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    time_zone VARCHAR NOT NULL DEFAULT '',
+    action VARCHAR NOT NULL CHECK (action IN ('skip', 'defer')),
+    created_at TIMESTAMP NOT NULL
 );
 
 CREATE TABLE backups (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
-    trigger VARCHAR NOT NULL CHECK (trigger IN ('manual', 'cron')),
-    status VARCHAR NOT NULL CHECK (status IN ('initialized', 'completed', 'failed')),
+    trigger VARCHAR NOT NULL CHECK (trigger IN ('manual', 'cron', 'pre-restore', 'import')),
+    status VARCHAR NOT NULL CHECK (status IN ('initialized', 'completed', 'failed', 'skipped')),
     file_path VARCHAR,
     file_original_name VARCHAR,
     file_size BIGINT,
+    checksum VARCHAR NOT NULL DEFAULT '',
     started_at TIMESTAMP,
     finished_at TIMESTAMP,
-    restored_at TIMESTAMP
+    restored_at TIMESTAMP,
+    pre_restore_of UUID REFERENCES backups(id) ON DELETE SET NULL,
+    dump_filter JSONB NOT NULL DEFAULT '{}',
+    globals_file_path VARCHAR NOT NULL DEFAULT '',
+    server_version VARCHAR NOT NULL DEFAULT '',
+    client_version VARCHAR NOT NULL DEFAULT '',
+    schema_only BOOLEAN NOT NULL DEFAULT false,
+    schedule_id UUID REFERENCES schedules(id) ON DELETE SET NULL,
+    skipped_by UUID REFERENCES blackout_windows(id) ON DELETE SET NULL
+);
+
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID REFERENCES datasources(id) ON DELETE CASCADE,
+    url VARCHAR NOT NULL,
+    secret VARCHAR NOT NULL,
+    events TEXT[] NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL,
+    response_status INTEGER,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    last_attempt_at TIMESTAMP
+);
+
+CREATE TABLE email_recipients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID REFERENCES datasources(id) ON DELETE CASCADE,
+    email VARCHAR NOT NULL,
+    alerts BOOLEAN NOT NULL,
+    digest BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE chat_channels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID REFERENCES datasources(id) ON DELETE CASCADE,
+    kind VARCHAR NOT NULL CHECK (kind IN ('slack', 'teams')),
+    webhook_url VARCHAR NOT NULL,
+    severity VARCHAR NOT NULL CHECK (severity IN ('failures', 'all')),
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE users (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR NOT NULL,
+    email VARCHAR NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR NOT NULL UNIQUE,
+    description TEXT,
+    permissions TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE user_grants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    datasource_id UUID REFERENCES datasources(id) ON DELETE CASCADE,
+    tag VARCHAR,
+    created_at TIMESTAMP NOT NULL,
+    CHECK (datasource_id IS NULL OR tag IS NULL)
+);
+
+INSERT INTO roles (name, description, permissions, created_at) VALUES
+    ('admin', 'Acesso total', ARRAY['datasource:read', 'datasource:write', 'backup:read', 'backup:create', 'backup:restore', 'backup:delete', 'admin'], NOW()),
+    ('operator', 'Consulta, backup e restauração', ARRAY['datasource:read', 'backup:read', 'backup:create', 'backup:restore'], NOW()),
+    ('developer', 'Consulta e backup manual', ARRAY['datasource:read', 'backup:read', 'backup:create'], NOW()),
+    ('viewer', 'Somente leitura', ARRAY['datasource:read', 'backup:read'], NOW());
+
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR NOT NULL,
+    prefix VARCHAR NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    key_hash VARCHAR NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE audit_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    action VARCHAR NOT NULL,
+    resource_type VARCHAR NOT NULL,
+    resource_id VARCHAR NOT NULL,
+    actor_id VARCHAR NOT NULL,
+    actor_name VARCHAR NOT NULL,
+    actor_kind VARCHAR NOT NULL,
+    actor_user_id UUID,
+    request_id VARCHAR NOT NULL,
+    client_ip VARCHAR NOT NULL,
+    before JSONB,
+    after JSONB,
+    changes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_entries_created_at_idx ON audit_entries (created_at DESC);
+CREATE INDEX audit_entries_resource_idx ON audit_entries (resource_type, resource_id);
+
+-- A trilha de auditoria é somente inserção
+CREATE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries é somente inserção';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_entries_no_update_delete
+    BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+
+CREATE TRIGGER audit_entries_no_truncate
+    BEFORE TRUNCATE ON audit_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_entries_append_only();
+
+CREATE TABLE restore_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    backup_id UUID REFERENCES backups(id) ON DELETE SET NULL,
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    status VARCHAR NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    reason TEXT NOT NULL DEFAULT '',
+    scope JSONB NOT NULL DEFAULT '{}',
+    requested_by VARCHAR NOT NULL,
+    requested_by_name VARCHAR NOT NULL,
+    decided_by VARCHAR,
+    decided_by_name VARCHAR,
+    decision_comment TEXT,
+    decided_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE temporary_databases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    backup_id UUID NOT NULL,
+    database VARCHAR NOT NULL,
+    registered_datasource_id UUID REFERENCES datasources(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    dropped_at TIMESTAMP,
+    error TEXT
+);
+
+CREATE TABLE restores (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    backup_id UUID REFERENCES backups(id) ON DELETE SET NULL,
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    database VARCHAR NOT NULL,
+    mode VARCHAR NOT NULL CHECK (mode IN ('overwrite', 'new_database', 'data_directory')),
+    scope JSONB NOT NULL DEFAULT '{}',
+    recovery_target JSONB,
+    status VARCHAR NOT NULL CHECK (status IN ('running', 'completed', 'failed')),
+    requested_by VARCHAR NOT NULL,
+    requested_by_name VARCHAR NOT NULL,
+    restore_request_id UUID REFERENCES restore_requests(id) ON DELETE SET NULL,
+    pre_restore_backup_id UUID REFERENCES backups(id) ON DELETE SET NULL,
+    temporary_database_id UUID REFERENCES temporary_databases(id) ON DELETE SET NULL,
+    error TEXT,
+    output TEXT,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX restores_datasource_id_idx ON restores (datasource_id, started_at DESC);
+
+CREATE TABLE wal_segments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    datasource_id UUID NOT NULL REFERENCES datasources(id) ON DELETE CASCADE,
+    file_name VARCHAR NOT NULL,
+    file_path VARCHAR NOT NULL,
+    file_size BIGINT NOT NULL,
+    checksum VARCHAR NOT NULL,
+    archived_at TIMESTAMP NOT NULL,
+    UNIQUE (datasource_id, file_name)
+);
+
+CREATE INDEX wal_segments_archived_at_idx ON wal_segments (datasource_id, archived_at DESC);
+
+CREATE TABLE backup_contents (
+    backup_id UUID PRIMARY KEY REFERENCES backups(id) ON DELETE CASCADE,
+    contents JSONB NOT NULL,
+    computed_at TIMESTAMP NOT NULL
 );
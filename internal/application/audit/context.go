@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// RequestInfo contém os dados da requisição HTTP associados aos registros de auditoria.
+type RequestInfo struct {
+	RequestId string
+	ClientIP  string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo retorna um contexto contendo os dados da requisição.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext retorna os dados da requisição, se houver.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields são os nomes de campos (em minúsculas) cujos valores nunca são gravados na auditoria.
+var sensitiveFields = map[string]bool{
+	"password":    true,
+	"secret":      true,
+	"webhook_url": true,
+	"key":         true,
+	"key_hash":    true,
+	"token":       true,
+}
+
+type Recorder struct {
+	auditRepo contract.IAuditRepository
+}
+
+var _ contract.IRecorder = (*Recorder)(nil)
+
+func NewRecorder(auditRepo contract.IAuditRepository) *Recorder {
+	return &Recorder{auditRepo}
+}
+
+// Record grava o registro de auditoria da operação.
+//
+// Parâmetros:
+// - ctx: contexto da requisição, de onde são lidos o principal autenticado, o request ID e o IP do cliente.
+// - action: ação executada.
+// - resourceType e resourceId: recurso afetado.
+// - before e after: estado do recurso antes e depois da operação; os segredos são mascarados.
+//
+// Falhas ao gravar são registradas em log e não interrompem a operação já concluída.
+func (r *Recorder) Record(ctx context.Context, action entity.Action, resourceType, resourceId string, before, after any) {
+	actor := entity.Actor{ID: "anonymous", Name: "anonymous", Kind: "anonymous"}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		actor = entity.Actor{
+			ID:     principal.ID,
+			Name:   principal.Name,
+			Kind:   string(principal.Kind),
+			UserId: principal.UserId,
+		}
+	}
+
+	entry := entity.NewEntry(action, resourceType, resourceId, actor)
+	if info, ok := RequestInfoFromContext(ctx); ok {
+		entry.RequestId = info.RequestId
+		entry.ClientIP = info.ClientIP
+	}
+
+	beforeSnapshot := snapshot(before)
+	afterSnapshot := snapshot(after)
+	// As alterações são calculadas antes do mascaramento para que a troca de um segredo
+	// apareça em Changes sem expor o valor.
+	entry.Changes = changedFields(beforeSnapshot, afterSnapshot)
+	redact(beforeSnapshot)
+	redact(afterSnapshot)
+	entry.Before = marshalSnapshot(beforeSnapshot)
+	entry.After = marshalSnapshot(afterSnapshot)
+
+	if err := r.auditRepo.CreateEntry(*entry); err != nil {
+		log.Printf("[AUDIT ERROR] Action: %s, Resource: %s/%s, Error: %s", action, resourceType, resourceId, err)
+	}
+}
+
+// snapshot converte o valor na sua representação JSON genérica.
+func snapshot(value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil
+	}
+	return object
+}
+
+func redact(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				if item != nil && item != "" {
+					v[key] = redacted
+				}
+				continue
+			}
+			redact(item)
+		}
+	case []any:
+		for _, item := range v {
+			redact(item)
+		}
+	}
+}
+
+func changedFields(before, after map[string]any) []string {
+	changes := make([]string, 0)
+	if before == nil && after == nil {
+		return changes
+	}
+	keys := make(map[string]bool, len(before)+len(after))
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	for key := range keys {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			changes = append(changes, key)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func marshalSnapshot(object map[string]any) json.RawMessage {
+	if object == nil {
+		return nil
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("credencial inválida")
+
+	// lastUsedInterval limita a frequência de gravação de last_used_at por chave.
+	lastUsedInterval = time.Minute
+)
+
+type ApiKeyAuthenticator struct {
+	apiKeyRepo    contract.IApiKeyRepository
+	userRepo      contract.IUserRepository
+	bootstrapHash string
+	lastUsed      map[string]time.Time
+	lastUsedLock  sync.Mutex
+}
+
+var _ contract.IAuthenticator = (*ApiKeyAuthenticator)(nil)
+
+// NewApiKeyAuthenticator cria o autenticador de chaves de API.
+//
+// Parâmetros:
+// - apiKeyRepo: repositório com os hashes das chaves cadastradas.
+// - userRepo: repositório de usuários, usado para carregar as concessões das chaves vinculadas a um usuário.
+// - bootstrapKey: chave administrativa definida via ambiente (BOOTSTRAP_API_KEY), usada para
+// criar as primeiras chaves. Pode ser vazia para desabilitar.
+func NewApiKeyAuthenticator(apiKeyRepo contract.IApiKeyRepository, userRepo contract.IUserRepository, bootstrapKey string) *ApiKeyAuthenticator {
+	bootstrapHash := ""
+	if bootstrapKey != "" {
+		bootstrapHash = entity.HashApiKey(bootstrapKey)
+	}
+	return &ApiKeyAuthenticator{
+		apiKeyRepo:    apiKeyRepo,
+		userRepo:      userRepo,
+		bootstrapHash: bootstrapHash,
+		lastUsed:      make(map[string]time.Time),
+	}
+}
+
+// Authenticate valida a chave de API e retorna o principal com os escopos da chave.
+//
+// Chaves vinculadas a um usuário recebem as concessões do usuário, limitadas pelos escopos da chave.
+// Chaves sem usuário recebem uma concessão global equivalente aos seus escopos.
+//
+// Retorna ErrInvalidCredentials caso a chave não exista, esteja revogada ou o usuário esteja desabilitado.
+func (a *ApiKeyAuthenticator) Authenticate(credential string) (entity.Principal, error) {
+	if credential == "" {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+	keyHash := entity.HashApiKey(credential)
+
+	if a.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(keyHash), []byte(a.bootstrapHash)) == 1 {
+		return entity.NewScopedPrincipal("bootstrap", "bootstrap", entity.PrincipalBootstrap, []entity.Scope{entity.ScopeAdmin}), nil
+	}
+
+	apiKey, err := a.apiKeyRepo.GetApiKeyByHash(keyHash)
+	if err != nil || apiKey.IsRevoked() {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+
+	principal := entity.NewScopedPrincipal(apiKey.ID, apiKey.Name, entity.PrincipalApiKey, apiKey.Scopes)
+	if apiKey.UserId != nil {
+		user, err := a.userRepo.GetUser(*apiKey.UserId)
+		if err != nil || !user.Enabled {
+			return entity.Principal{}, ErrInvalidCredentials
+		}
+		grants, err := a.userRepo.GetGrants(user.ID)
+		if err != nil {
+			return entity.Principal{}, err
+		}
+		principal.UserId = &user.ID
+		principal.Grants = grants
+	}
+
+	a.touch(apiKey.ID)
+
+	return principal, nil
+}
+
+func (a *ApiKeyAuthenticator) touch(apiKeyId string) {
+	now := time.Now()
+
+	a.lastUsedLock.Lock()
+	if last, ok := a.lastUsed[apiKeyId]; ok && now.Sub(last) < lastUsedInterval {
+		a.lastUsedLock.Unlock()
+		return
+	}
+	a.lastUsed[apiKeyId] = now
+	a.lastUsedLock.Unlock()
+
+	go func() {
+		if err := a.apiKeyRepo.TouchApiKey(apiKeyId, now); err != nil {
+			log.Printf("erro ao atualizar o último uso da chave %s: %v", apiKeyId, err)
+		}
+	}()
+}
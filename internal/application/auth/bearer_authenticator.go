@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/jwt"
+)
+
+// BearerAuthenticator direciona a credencial ao autenticador adequado:
+// tokens no formato JWT vão para o OIDC e as demais credenciais são tratadas como chaves de API.
+type BearerAuthenticator struct {
+	apiKey contract.IAuthenticator
+	oidc   contract.IAuthenticator
+}
+
+var _ contract.IAuthenticator = (*BearerAuthenticator)(nil)
+
+// NewBearerAuthenticator cria o autenticador combinado. oidc pode ser nil quando o OIDC não está configurado.
+func NewBearerAuthenticator(apiKey, oidc contract.IAuthenticator) *BearerAuthenticator {
+	return &BearerAuthenticator{apiKey: apiKey, oidc: oidc}
+}
+
+// Authenticate implements IAuthenticator.
+func (a *BearerAuthenticator) Authenticate(credential string) (entity.Principal, error) {
+	if jwt.LooksLikeJWT(credential) {
+		if a.oidc == nil {
+			return entity.Principal{}, ErrInvalidCredentials
+		}
+		return a.oidc.Authenticate(credential)
+	}
+	return a.apiKey.Authenticate(credential)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+)
+
+type principalKey struct{}
+
+// WithPrincipal retorna um contexto contendo o principal autenticado.
+func WithPrincipal(ctx context.Context, principal entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext retorna o principal autenticado da requisição, se houver.
+func PrincipalFromContext(ctx context.Context) (entity.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(entity.Principal)
+	return principal, ok
+}
+
+// Can indica se o principal da requisição pode exercer a permissão sobre o datasource informado.
+// Retorna false quando não há principal autenticado no contexto.
+func Can(ctx context.Context, permission entity.Permission, datasourceId string, tags []string) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return principal.Can(permission, datasourceId, tags)
+}
+
+// CanAny indica se o principal da requisição pode exercer a permissão em ao menos um datasource; a permissão admin
+// só é considerada quando concedida globalmente (ver Principal.CanAny).
+func CanAny(ctx context.Context, permission entity.Permission) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return principal.CanAny(permission)
+}
@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/jwt"
+)
+
+var (
+	// jwksRefreshInterval define de quanto em quanto tempo as chaves do emissor são recarregadas.
+	jwksRefreshInterval = time.Hour
+	// jwksMinRefreshInterval limita recargas forçadas por tokens com "kid" desconhecido.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// OIDCConfig contém a configuração do emissor OIDC aceito pela API.
+type OIDCConfig struct {
+	Issuer      string
+	Audience    string
+	JWKSURL     string
+	GroupsClaim string
+	// GroupRoles mapeia grupos do token para nomes de roles cadastradas.
+	GroupRoles  map[string]string
+	DefaultRole string
+}
+
+// OIDCConfigFromEnv lê a configuração OIDC das variáveis de ambiente.
+// Retorna false quando OIDC_ISSUER não está definido e erro quando OIDC_AUDIENCE não está: sem a audiência, tokens
+// emitidos pelo mesmo provedor para outros clientes seriam aceitos.
+//
+// OIDC_ROLE_MAPPING tem o formato "grupo=role,outro-grupo=outra-role".
+func OIDCConfigFromEnv() (OIDCConfig, bool, error) {
+	issuer := strings.TrimSuffix(os.Getenv("OIDC_ISSUER"), "/")
+	if issuer == "" {
+		return OIDCConfig{}, false, nil
+	}
+	audience := os.Getenv("OIDC_AUDIENCE")
+	if audience == "" {
+		return OIDCConfig{}, false, fmt.Errorf("OIDC_AUDIENCE é obrigatória quando OIDC_ISSUER está definida")
+	}
+
+	groupsClaim := os.Getenv("OIDC_GROUPS_CLAIM")
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+
+	groupRoles := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv("OIDC_ROLE_MAPPING"), ",") {
+		group, role, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		groupRoles[strings.TrimSpace(group)] = strings.TrimSpace(role)
+	}
+
+	return OIDCConfig{
+		Issuer:      issuer,
+		Audience:    audience,
+		JWKSURL:     os.Getenv("OIDC_JWKS_URL"),
+		GroupsClaim: groupsClaim,
+		GroupRoles:  groupRoles,
+		DefaultRole: os.Getenv("OIDC_DEFAULT_ROLE"),
+	}, true, nil
+}
+
+type OIDCAuthenticator struct {
+	config   OIDCConfig
+	userRepo contract.IUserRepository
+	roleRepo contract.IRoleRepository
+	client   *http.Client
+
+	keys      jwt.KeySet
+	jwksURL   string
+	fetchedAt time.Time
+	keysLock  sync.Mutex
+}
+
+var _ contract.IAuthenticator = (*OIDCAuthenticator)(nil)
+
+// NewOIDCAuthenticator cria o autenticador de tokens JWT emitidos pelo provedor OIDC configurado.
+//
+// Parâmetros:
+// - config: emissor, audiência e mapeamento de grupos para roles.
+// - userRepo: repositório de usuários, usado para vincular o token a um usuário cadastrado pelo e-mail verificado.
+// - roleRepo: repositório de roles, usado para resolver os grupos do token.
+func NewOIDCAuthenticator(config OIDCConfig, userRepo contract.IUserRepository, roleRepo contract.IRoleRepository) *OIDCAuthenticator {
+	return &OIDCAuthenticator{
+		config:   config,
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+		client:   jwt.NewHTTPClient(),
+		jwksURL:  config.JWKSURL,
+	}
+}
+
+// Authenticate valida o token JWT (assinatura, emissor, audiência e validade) e retorna o principal.
+//
+// Quando o e-mail do token está verificado ("email_verified") e corresponde a um usuário cadastrado, o principal
+// recebe as concessões do usuário.
+// Os grupos do token mapeados em OIDC_ROLE_MAPPING (ou OIDC_DEFAULT_ROLE) acrescentam concessões globais.
+//
+// Retorna ErrInvalidCredentials caso o token seja inválido ou o usuário esteja desabilitado.
+func (a *OIDCAuthenticator) Authenticate(credential string) (entity.Principal, error) {
+	claims, err := a.verify(credential)
+	if err != nil {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+	if claims.Issuer != a.config.Issuer {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+	if a.config.Audience == "" || !claims.HasAudience(a.config.Audience) {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+	if claims.Subject == "" {
+		return entity.Principal{}, ErrInvalidCredentials
+	}
+
+	email := claims.String("email")
+	name := claims.String("name")
+	if name == "" {
+		name = claims.String("preferred_username")
+	}
+	if name == "" {
+		name = email
+	}
+	if name == "" {
+		name = claims.Subject
+	}
+
+	principal := entity.Principal{
+		ID:     claims.Subject,
+		Name:   name,
+		Email:  email,
+		Kind:   entity.PrincipalOIDC,
+		Scopes: entity.AllScopes,
+		Grants: make([]entity.Grant, 0),
+	}
+
+	// Sem a verificação do provedor, o e-mail pode ter sido informado livremente pelo usuário.
+	if email != "" && claims.Bool("email_verified") {
+		user, err := a.userRepo.GetUserByEmail(email)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// usuário não cadastrado: valem apenas as roles mapeadas pelos grupos
+		case err != nil:
+			return entity.Principal{}, err
+		case !user.Enabled:
+			return entity.Principal{}, ErrInvalidCredentials
+		default:
+			grants, err := a.userRepo.GetGrants(user.ID)
+			if err != nil {
+				return entity.Principal{}, err
+			}
+			principal.UserId = &user.ID
+			principal.Grants = append(principal.Grants, grants...)
+		}
+	}
+
+	for _, roleName := range a.mappedRoles(claims) {
+		role, err := a.roleRepo.GetRoleByName(roleName)
+		if err != nil {
+			log.Printf("role %q mapeada via OIDC não encontrada: %v", roleName, err)
+			continue
+		}
+		principal.Grants = append(principal.Grants, entity.Grant{
+			RoleId:      role.ID,
+			RoleName:    role.Name,
+			Permissions: role.Permissions,
+		})
+	}
+
+	return principal, nil
+}
+
+func (a *OIDCAuthenticator) mappedRoles(claims jwt.Claims) []string {
+	seen := make(map[string]bool)
+	roles := make([]string, 0)
+	add := func(role string) {
+		if role != "" && !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+
+	for _, group := range jwt.StringsClaim(claims.Raw, a.config.GroupsClaim) {
+		add(a.config.GroupRoles[group])
+	}
+	add(a.config.DefaultRole)
+	return roles
+}
+
+func (a *OIDCAuthenticator) verify(token string) (jwt.Claims, error) {
+	header, err := jwt.ParseHeader(token)
+	if err != nil {
+		return jwt.Claims{}, err
+	}
+	keys, err := a.keySet(header.Kid)
+	if err != nil {
+		return jwt.Claims{}, err
+	}
+	return jwt.Verify(token, keys)
+}
+
+// keySet retorna as chaves do emissor, recarregando-as quando expiradas ou quando o "kid" é desconhecido.
+func (a *OIDCAuthenticator) keySet(kid string) (jwt.KeySet, error) {
+	a.keysLock.Lock()
+	defer a.keysLock.Unlock()
+
+	_, known := a.keys[kid]
+	age := time.Since(a.fetchedAt)
+	if a.keys != nil && age < jwksRefreshInterval && (known || age < jwksMinRefreshInterval) {
+		return a.keys, nil
+	}
+
+	if a.jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := jwt.FetchJSON(a.client, a.config.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, fmt.Errorf("erro na descoberta OIDC: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("descoberta OIDC sem jwks_uri")
+		}
+		a.jwksURL = discovery.JWKSURI
+	}
+
+	keys, err := jwt.FetchKeySet(a.client, a.jwksURL)
+	if err != nil {
+		if a.keys != nil {
+			log.Printf("erro ao recarregar o JWKS, mantendo as chaves anteriores: %v", err)
+			return a.keys, nil
+		}
+		return nil, err
+	}
+	a.keys = keys
+	a.fetchedAt = time.Now()
+	return keys, nil
+}
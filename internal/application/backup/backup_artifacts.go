@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+// importExtensions são as extensões aceitas na importação, as mesmas geradas pelo serviço de backup.
+var importExtensions = []string{".sql.gz", ".backup.gz", ".tar.gz", ".dir.tar"}
+
+type BackupArtifacts struct {
+	backupRepo contract.IBackupRepository
+}
+
+var _ contract.IBackupArtifacts = (*BackupArtifacts)(nil)
+
+func NewBackupArtifacts(backupRepo contract.IBackupRepository) *BackupArtifacts {
+	return &BackupArtifacts{backupRepo}
+}
+
+// Import implements IBackupArtifacts.
+//
+// São aceitos arquivos .sql.gz (script do pg_dump), .backup.gz (formato custom), .tar.gz (formato tar) e
+// .dir.tar (formato directory empacotado). O arquivo é lido por completo para validar a compactação, e o
+// conteúdo precisa corresponder ao formato da extensão.
+func (ba *BackupArtifacts) Import(ds entity.Datasource, fileName string, r io.Reader) (entity.Backup, error) {
+	fileName = filepath.Base(fileName)
+	ext := utils.GetFullFileExtension(fileName)
+	if !slices.Contains(importExtensions, ext) {
+		return entity.Backup{}, fmt.Errorf("%w: use um arquivo %s", entity.ErrInvalidBackupFile, strings.Join(importExtensions, ", "))
+	}
+
+	backup := entity.NewBackup(ds.ID, entity.BackupImport)
+	backup.SetStartedAt()
+	filePath := fmt.Sprintf("./backups/%s-%d-import-%s%s", ds.Database, time.Now().Unix(), backup.ID[:8], ext)
+
+	out, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return entity.Backup{}, fmt.Errorf("erro ao criar o arquivo de backup: %w", err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), r)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return entity.Backup{}, fmt.Errorf("erro ao gravar o arquivo de backup: %w", err)
+	}
+
+	if err := validateBackupFile(filePath, ext); err != nil {
+		os.Remove(filePath)
+		return entity.Backup{}, err
+	}
+
+	backup.FilePath = filePath
+	backup.FileOriginalName = fileName
+	backup.FileSize = size
+	backup.Checksum = hex.EncodeToString(hash.Sum(nil))
+	backup.SetCompleted()
+	backup.SetFinishedAt()
+	if err := ba.backupRepo.CreateBackup(*backup); err != nil {
+		os.Remove(filePath)
+		return entity.Backup{}, fmt.Errorf("erro ao registrar o backup: %w", err)
+	}
+	return *backup, nil
+}
+
+// Checksum implements IBackupArtifacts.
+func (ba *BackupArtifacts) Checksum(backup entity.Backup) (entity.Backup, error) {
+	if backup.Checksum != "" {
+		return backup, nil
+	}
+	checksum, err := fileChecksum(backup.FilePath)
+	if err != nil {
+		return backup, err
+	}
+	backup.Checksum = checksum
+	if err := ba.backupRepo.UpdateBackup(backup); err != nil {
+		return backup, fmt.Errorf("erro ao salvar o checksum do backup: %w", err)
+	}
+	return backup, nil
+}
+
+// validateBackupFile descompacta o arquivo por completo (o gzip valida o CRC ao final) e confere o início do
+// conteúdo: a assinatura "PGDMP" no formato custom, o cabeçalho "ustar" no tar ou o cabeçalho do pg_dump no
+// formato plain. No formato directory, o tar precisa conter o toc.dat.
+func validateBackupFile(filePath, ext string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if ext == ".dir.tar" {
+		return validateDirectoryArchive(file)
+	}
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("%w: o arquivo não está compactado com gzip", entity.ErrInvalidBackupFile)
+	}
+	defer gzipReader.Close()
+
+	head := make([]byte, 4096)
+	n, err := io.ReadFull(gzipReader, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("%w: arquivo compactado corrompido", entity.ErrInvalidBackupFile)
+	}
+	head = head[:n]
+
+	switch ext {
+	case ".backup.gz":
+		if !bytes.HasPrefix(head, []byte("PGDMP")) {
+			return fmt.Errorf("%w: o conteúdo não é um backup no formato custom do pg_dump", entity.ErrInvalidBackupFile)
+		}
+	case ".tar.gz":
+		if len(head) < 262 || string(head[257:262]) != "ustar" {
+			return fmt.Errorf("%w: o conteúdo não é um arquivo tar", entity.ErrInvalidBackupFile)
+		}
+	default:
+		if !bytes.Contains(head, []byte("PostgreSQL database dump")) {
+			return fmt.Errorf("%w: o conteúdo não é um script gerado pelo pg_dump", entity.ErrInvalidBackupFile)
+		}
+	}
+
+	if _, err := io.Copy(io.Discard, gzipReader); err != nil {
+		return fmt.Errorf("%w: arquivo compactado corrompido", entity.ErrInvalidBackupFile)
+	}
+	return nil
+}
+
+// validateDirectoryArchive percorre o tar do formato directory, exigindo o toc.dat na raiz.
+func validateDirectoryArchive(r io.Reader) error {
+	tr := tar.NewReader(r)
+	hasTOC := false
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: arquivo tar corrompido", entity.ErrInvalidBackupFile)
+		}
+		if header.Name == "toc.dat" {
+			hasTOC = true
+		}
+	}
+	if !hasTOC {
+		return fmt.Errorf("%w: o arquivo não contém um backup no formato directory do pg_dump (toc.dat)", entity.ErrInvalidBackupFile)
+	}
+	return nil
+}
+
+// fileChecksum calcula o SHA-256 (hex) de um arquivo.
+func fileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
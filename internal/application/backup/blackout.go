@@ -0,0 +1,48 @@
+package backup
+
+import (
+	"log"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+// activeBlackout retorna a janela de bloqueio em andamento no instante informado e o fim da sua ocorrência. Entre
+// janelas simultâneas, skip prevalece sobre defer e, entre as de mesma ação, vale a que termina por último.
+func activeBlackout(windows []entity.BlackoutWindow, now time.Time) (entity.BlackoutWindow, time.Time, bool) {
+	var (
+		active    entity.BlackoutWindow
+		activeEnd time.Time
+		found     bool
+	)
+	for _, window := range windows {
+		end, ok := occurrenceEnd(window, now)
+		if !ok {
+			continue
+		}
+		replaces := !found ||
+			(window.Action == entity.BlackoutSkip && active.Action != entity.BlackoutSkip) ||
+			(window.Action == active.Action && end.After(activeEnd))
+		if replaces {
+			active, activeEnd, found = window, end, true
+		}
+	}
+	return active, activeEnd, found
+}
+
+// occurrenceEnd retorna o fim da ocorrência da janela em andamento no instante informado. Ocorrências sobrepostas
+// são tratadas como uma só, que termina com a última.
+func occurrenceEnd(window entity.BlackoutWindow, now time.Time) (time.Time, bool) {
+	schedule, err := cronParser.Parse(window.CronSpec())
+	if err != nil {
+		log.Printf("Janela de bloqueio %s ignorada: expressão cron inválida: %v", window.ID, err)
+		return time.Time{}, false
+	}
+	duration := window.Duration()
+	var end time.Time
+	// O agendador retorna o instante zero quando não há próxima ocorrência.
+	for start := schedule.Next(now.Add(-duration)); !start.IsZero() && !start.After(now); start = schedule.Next(start) {
+		end = start.Add(duration)
+	}
+	return end, !end.IsZero()
+}
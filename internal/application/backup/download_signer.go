@@ -0,0 +1,73 @@
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+)
+
+type DownloadSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+var _ contract.IDownloadSigner = (*DownloadSigner)(nil)
+
+func NewDownloadSigner(secret []byte, ttl time.Duration) *DownloadSigner {
+	return &DownloadSigner{secret, ttl}
+}
+
+// NewDownloadSignerFromEnv lê BACKUP_DOWNLOAD_URL_SECRET e BACKUP_DOWNLOAD_URL_TTL (padrão 5m). Sem segredo,
+// é gerado um aleatório e as URLs emitidas deixam de valer quando o serviço reinicia.
+func NewDownloadSignerFromEnv() *DownloadSigner {
+	secret := []byte(os.Getenv("BACKUP_DOWNLOAD_URL_SECRET"))
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("Erro ao gerar o segredo das URLs de download: %s", err)
+		}
+		log.Println("BACKUP_DOWNLOAD_URL_SECRET não definido, usando um segredo aleatório")
+	}
+
+	ttl := 5 * time.Minute
+	if value := os.Getenv("BACKUP_DOWNLOAD_URL_TTL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			log.Printf("BACKUP_DOWNLOAD_URL_TTL inválido (%q), usando %s", value, ttl)
+		} else {
+			ttl = parsed
+		}
+	}
+	return NewDownloadSigner(secret, ttl)
+}
+
+// Sign implements IDownloadSigner.
+func (ds *DownloadSigner) Sign(backupId string) (string, time.Time) {
+	expiresAt := time.Now().Add(ds.ttl).Truncate(time.Second)
+	return ds.signature(backupId, strconv.FormatInt(expiresAt.Unix(), 10)), expiresAt
+}
+
+// Verify implements IDownloadSigner.
+func (ds *DownloadSigner) Verify(backupId, expires, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(ds.signature(backupId, expires)), []byte(signature))
+}
+
+// signature calcula o HMAC-SHA256 (hex) de "<backupId>.<expires>".
+func (ds *DownloadSigner) signature(backupId, expires string) string {
+	mac := hmac.New(sha256.New, ds.secret)
+	mac.Write([]byte(backupId))
+	mac.Write([]byte("."))
+	mac.Write([]byte(expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
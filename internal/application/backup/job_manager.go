@@ -11,27 +11,47 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// cronParser interpreta as expressões como o agendador (cron.WithSeconds).
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ValidateCronExpr verifica se a expressão é aceita pelo agendador, que tem o campo de segundos.
+func ValidateCronExpr(expr string) error {
+	_, err := cronParser.Parse(expr)
+	return err
+}
+
 type JobManager struct {
 	cron           *cron.Cron
 	jobs           map[string]cron.EntryID
 	jobExprs       map[string]string
 	jobLock        sync.Mutex
 	datasourceRepo contract.IDatasourceRepository
+	profileRepo    contract.IBackupProfileRepository
+	scheduleRepo   contract.IScheduleRepository
+	blackoutRepo   contract.IBlackoutWindowRepository
 	ctx            context.Context
 	cancelCtx      context.CancelFunc
 	jobCommand     contract.ICommand
+
+	// deferred são as execuções adiadas por janelas de bloqueio, uma por tarefa.
+	deferred     map[string]*time.Timer
+	deferredLock sync.Mutex
 }
 
-func NewJobManager(datasourceRepo contract.IDatasourceRepository, jobCommand contract.ICommand) *JobManager {
+func NewJobManager(datasourceRepo contract.IDatasourceRepository, profileRepo contract.IBackupProfileRepository, scheduleRepo contract.IScheduleRepository, blackoutRepo contract.IBlackoutWindowRepository, jobCommand contract.ICommand) *JobManager {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &JobManager{
 		cron:           cron.New(cron.WithSeconds()),
 		jobs:           make(map[string]cron.EntryID),
 		jobExprs:       make(map[string]string),
 		datasourceRepo: datasourceRepo,
+		profileRepo:    profileRepo,
+		scheduleRepo:   scheduleRepo,
+		blackoutRepo:   blackoutRepo,
 		ctx:            ctx,
 		cancelCtx:      cancel,
 		jobCommand:     jobCommand,
+		deferred:       make(map[string]*time.Timer),
 	}
 }
 
@@ -59,26 +79,75 @@ func (jm *JobManager) Start() {
 func (jm *JobManager) Stop() {
 	jm.cron.Stop()
 	jm.cancelCtx()
+
+	jm.deferredLock.Lock()
+	defer jm.deferredLock.Unlock()
+	for id, timer := range jm.deferred {
+		timer.Stop()
+		delete(jm.deferred, id)
+	}
 }
 
 func (jm *JobManager) LoadJobsFromDB() {
 	jm.jobLock.Lock()
 	defer jm.jobLock.Unlock()
 
-	enabled := true
-	datasources, err := jm.datasourceRepo.GetDatasources(&enabled)
+	datasources, err := jm.datasourceRepo.GetDatasources(nil)
 	if err != nil {
 		log.Printf("Erro ao carregar tarefas: %v", err)
 		return
 	}
 
+	schedules, err := jm.scheduleRepo.GetSchedules(nil)
+	if err != nil {
+		log.Printf("Erro ao carregar agendamentos: %v", err)
+		return
+	}
+
+	profiles, err := jm.profileRepo.GetBackupProfiles()
+	if err != nil {
+		log.Printf("Erro ao carregar perfis de backup: %v", err)
+		return
+	}
+	profilesByID := make(map[string]entity.BackupProfile, len(profiles))
+	for _, profile := range profiles {
+		profilesByID[profile.ID] = profile
+	}
+
+	// As tarefas são identificadas pelo id do datasource, no cron do próprio datasource, ou pelo id do agendamento.
 	activeTasks := make(map[string]entity.Datasource)
+	datasourcesByID := make(map[string]entity.Datasource, len(datasources))
 
 	for _, ds := range datasources {
+		datasourcesByID[ds.ID] = ds
+		if !ds.Cron.Enabled {
+			continue
+		}
+		// Com perfil, vale o cron do perfil: alterá-lo reagenda todos os datasources que o referenciam.
+		if ds.ProfileId != nil {
+			profile, ok := profilesByID[*ds.ProfileId]
+			if !ok {
+				log.Printf("Perfil de backup %s do datasource %s não encontrado", *ds.ProfileId, ds.ID)
+				continue
+			}
+			ds = profile.Apply(ds)
+		}
 		activeTasks[ds.ID] = ds
-		existingID, exists := jm.jobs[ds.ID]
+	}
+
+	// Os agendamentos são independentes do cron do datasource, que pode estar desativado.
+	for _, schedule := range schedules {
+		ds, ok := datasourcesByID[schedule.DatasourceId]
+		if !ok || !schedule.Enabled {
+			continue
+		}
+		activeTasks[schedule.ID] = schedule.Apply(ds)
+	}
+
+	for id, ds := range activeTasks {
+		existingID, exists := jm.jobs[id]
 		if exists {
-			isCronChanged := jm.jobExprs[ds.ID] != ds.Cron.CronExpr
+			isCronChanged := jm.jobExprs[id] != ds.Cron.Spec()
 			if isCronChanged {
 				jm.cron.Remove(existingID)
 			} else {
@@ -87,14 +156,14 @@ func (jm *JobManager) LoadJobsFromDB() {
 		}
 
 		// Adiciona (ou re-adiciona) a tarefa
-		entryID, err := jm.cron.AddFunc(ds.Cron.CronExpr, jm.jobCommand.Command(ds, entity.BackupCron))
+		entryID, err := jm.cron.AddFunc(ds.Cron.Spec(), jm.scheduledJob(id, ds))
 
 		if err != nil {
-			log.Printf("Erro ao adicionar tarefa ID %s: %v", ds.ID, err)
+			log.Printf("Erro ao adicionar tarefa ID %s: %v", id, err)
 			continue
 		}
-		jm.jobs[ds.ID] = entryID
-		jm.jobExprs[ds.ID] = ds.Cron.CronExpr
+		jm.jobs[id] = entryID
+		jm.jobExprs[id] = ds.Cron.Spec()
 	}
 
 	for id, entryID := range jm.jobs {
@@ -102,6 +171,68 @@ func (jm *JobManager) LoadJobsFromDB() {
 			jm.cron.Remove(entryID)
 			delete(jm.jobs, id)
 			delete(jm.jobExprs, id)
+			jm.cancelDeferred(id)
+		}
+	}
+}
+
+// scheduledJob retorna a tarefa agendada. O datasource da tarefa é relido a cada execução, para que alterações de
+// conexão, de opções ou de perfil valham sem reagendá-la; o perfil e o agendamento são aplicados pelo comando.
+//
+// Antes do backup, as janelas de bloqueio do datasource são consultadas. Sem conseguir consultá-las, o backup é
+// executado: perder um backup é pior que executá-lo durante um bloqueio.
+func (jm *JobManager) scheduledJob(id string, task entity.Datasource) func() {
+	return func() {
+		ds, err := jm.datasourceRepo.GetDatasource(task.ID)
+		if err != nil {
+			log.Printf("Erro ao carregar o datasource %s da tarefa %s: %v", task.ID, id, err)
+			return
+		}
+		ds.ScheduleId = task.ScheduleId
+
+		windows, err := jm.blackoutRepo.GetBlackoutWindows(ds.ID)
+		if err != nil {
+			log.Printf("Erro ao carregar as janelas de bloqueio do datasource %s: %v", ds.ID, err)
+		}
+		window, end, blocked := activeBlackout(windows, time.Now())
+		switch {
+		case !blocked:
+			jm.jobCommand.Run(ds, entity.BackupCron)
+		case window.Action == entity.BlackoutDefer:
+			jm.deferJob(id, task, ds, window, end)
+		default:
+			jm.jobCommand.Skip(ds, window)
 		}
 	}
 }
+
+// deferJob agenda a tarefa para o fim da janela de bloqueio, quando as janelas são consultadas novamente. As
+// execuções adiadas de uma mesma tarefa são reunidas em uma só.
+func (jm *JobManager) deferJob(id string, task, ds entity.Datasource, window entity.BlackoutWindow, until time.Time) {
+	jm.deferredLock.Lock()
+	defer jm.deferredLock.Unlock()
+
+	if _, pending := jm.deferred[id]; pending {
+		log.Printf("[JOB DEFERRED] Datasource: %s, já adiado pela janela de bloqueio %s", ds.Database, window.Name)
+		return
+	}
+	log.Printf("[JOB DEFERRED] Datasource: %s, adiado até %s pela janela de bloqueio %s", ds.Database, until.Format(time.RFC3339), window.Name)
+	job := jm.scheduledJob(id, task)
+	jm.deferred[id] = time.AfterFunc(time.Until(until), func() {
+		jm.deferredLock.Lock()
+		delete(jm.deferred, id)
+		jm.deferredLock.Unlock()
+		job()
+	})
+}
+
+// cancelDeferred descarta a execução adiada de uma tarefa removida do agendador.
+func (jm *JobManager) cancelDeferred(id string) {
+	jm.deferredLock.Lock()
+	defer jm.deferredLock.Unlock()
+
+	if timer, pending := jm.deferred[id]; pending {
+		timer.Stop()
+		delete(jm.deferred, id)
+	}
+}
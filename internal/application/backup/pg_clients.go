@@ -0,0 +1,209 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+// defaultPgBinGlobs são os diretórios de binários dos pacotes oficiais (Debian/Ubuntu e RHEL), usados sem PG_BIN_DIRS.
+var defaultPgBinGlobs = []string{"/usr/lib/postgresql/*/bin", "/usr/pgsql-*/bin"}
+
+// clientVersionPattern extrai a versão da saída de `pg_dump --version`, ex: "pg_dump (PostgreSQL) 15.4 (Debian 15.4-1)".
+var clientVersionPattern = regexp.MustCompile(`\(PostgreSQL\) (\d+)(\.\d+)*`)
+
+// ErrNoCompatibleClient indica que nenhum diretório de binários tem um pg_dump da versão do servidor ou mais recente.
+var ErrNoCompatibleClient = errors.New("nenhum cliente do PostgreSQL compatível com o servidor")
+
+// PgClient é um diretório de binários do PostgreSQL (pg_dump, pg_restore, psql, ...) de uma versão major.
+type PgClient struct {
+	// Dir é o diretório dos binários; vazio usa os binários do PATH.
+	Dir     string
+	Version string
+	Major   int
+}
+
+// Path retorna o caminho do executável no diretório do cliente.
+func (c PgClient) Path(executable string) string {
+	if c.Dir == "" {
+		return executable
+	}
+	return filepath.Join(c.Dir, executable)
+}
+
+// PgClients seleciona, para cada servidor, os binários do PostgreSQL da versão adequada. O pg_dump recusa
+// servidores de versão major mais recente que a sua ("server version mismatch").
+type PgClients struct {
+	// clients em ordem crescente de versão major, um por versão.
+	clients []PgClient
+}
+
+// PgClientsFromEnv lê PG_BIN_DIRS, lista de diretórios separados por ":" como no PATH. Sem a variável, são
+// usados os diretórios dos pacotes oficiais (/usr/lib/postgresql/<versão>/bin e /usr/pgsql-<versão>/bin).
+func PgClientsFromEnv() *PgClients {
+	var dirs []string
+	if value := os.Getenv("PG_BIN_DIRS"); value != "" {
+		dirs = filepath.SplitList(value)
+	} else {
+		for _, pattern := range defaultPgBinGlobs {
+			matches, _ := filepath.Glob(pattern)
+			dirs = append(dirs, matches...)
+		}
+	}
+	return NewPgClients(dirs)
+}
+
+// NewPgClients identifica a versão do pg_dump de cada diretório. Diretórios sem pg_dump são ignorados e,
+// entre diretórios da mesma versão major, vale o primeiro. Sem nenhum diretório válido, usa os binários do PATH.
+func NewPgClients(dirs []string) *PgClients {
+	clients := make([]PgClient, 0, len(dirs))
+	for _, dir := range dirs {
+		client, err := detectClient(dir)
+		if err != nil {
+			log.Printf("PG_BIN_DIRS: diretório %s ignorado: %v", dir, err)
+			continue
+		}
+		if slices.ContainsFunc(clients, func(c PgClient) bool { return c.Major == client.Major }) {
+			continue
+		}
+		clients = append(clients, client)
+	}
+	if len(clients) == 0 {
+		client, err := detectClient("")
+		if err != nil {
+			log.Printf("nenhum cliente do PostgreSQL encontrado: %v", err)
+		}
+		clients = append(clients, client)
+	}
+	slices.SortFunc(clients, func(a, b PgClient) int { return a.Major - b.Major })
+
+	versions := make([]string, len(clients))
+	for i, client := range clients {
+		versions[i] = client.Version
+	}
+	log.Printf("clientes do PostgreSQL disponíveis: %s", strings.Join(versions, ", "))
+	return &PgClients{clients}
+}
+
+// detectClient executa `pg_dump --version` no diretório.
+func detectClient(dir string) (PgClient, error) {
+	client := PgClient{Dir: dir}
+	output, err := exec.Command(client.Path("pg_dump"), "--version").Output()
+	if err != nil {
+		return client, err
+	}
+	match := clientVersionPattern.FindStringSubmatch(string(output))
+	if match == nil {
+		return client, fmt.Errorf("versão não reconhecida: %s", strings.TrimSpace(string(output)))
+	}
+	client.Major, _ = strconv.Atoi(match[1])
+	client.Version = strings.TrimPrefix(match[0], "(PostgreSQL) ")
+	return client, nil
+}
+
+// Newest retorna o cliente mais recente, usado nas operações que não dependem da versão do servidor, como ler
+// a TOC de um arquivo de backup ou executar SQL com o psql.
+func (pc *PgClients) Newest() PgClient {
+	return pc.clients[len(pc.clients)-1]
+}
+
+// Select retorna o cliente da versão major do servidor ou, sem ele, o de versão mais próxima acima. O mais
+// próximo é preferido ao mais recente porque os dumps de versões novas podem conter comandos que o servidor
+// não reconhece na restauração (ex: SET transaction_timeout, a partir da versão 17).
+func (pc *PgClients) Select(serverMajor int) (PgClient, error) {
+	for _, client := range pc.clients {
+		if client.Major >= serverMajor {
+			return client, nil
+		}
+	}
+	if newest := pc.Newest(); newest.Version != "" {
+		return PgClient{}, fmt.Errorf("%w %d: o mais recente é o %s (configure PG_BIN_DIRS)", ErrNoCompatibleClient, serverMajor, newest.Version)
+	}
+	return PgClient{}, fmt.Errorf("%w %d: pg_dump não encontrado (configure PG_BIN_DIRS)", ErrNoCompatibleClient, serverMajor)
+}
+
+// ForServer consulta a versão do servidor do datasource e seleciona o cliente com Select.
+//
+// Retorna:
+// - O cliente selecionado.
+// - As versões do servidor e do cliente, registradas nos backups.
+// - Um erro, caso a conexão falhe ou nenhum cliente seja compatível.
+func (pc *PgClients) ForServer(ds entity.Datasource) (PgClient, entity.PgVersions, error) {
+	serverMajor, serverVersion, err := pc.serverVersion(ds)
+	if err != nil {
+		return PgClient{}, entity.PgVersions{}, err
+	}
+	versions := entity.PgVersions{Server: serverVersion}
+	client, err := pc.Select(serverMajor)
+	if err != nil {
+		return PgClient{}, versions, err
+	}
+	versions.Client = client.Version
+	return client, versions, nil
+}
+
+// ForRestore seleciona o pg_restore para restaurar no datasource um arquivo gerado pelo pg_dump dumpVersion.
+// O pg_restore não lê arquivos de um pg_dump de versão major mais recente que a sua, então o cliente precisa
+// atender à maior versão entre a do servidor e a do backup. Sem dumpVersion, vale apenas a do servidor.
+func (pc *PgClients) ForRestore(ds entity.Datasource, dumpVersion string) (PgClient, error) {
+	serverMajor, _, err := pc.serverVersion(ds)
+	if err != nil {
+		return PgClient{}, err
+	}
+	dumpMajor, _ := strconv.Atoi(strings.Split(dumpVersion, ".")[0])
+	if dumpMajor <= serverMajor {
+		return pc.Select(serverMajor)
+	}
+	client, err := pc.Select(dumpMajor)
+	if err != nil {
+		return PgClient{}, fmt.Errorf("o backup foi gerado pelo pg_dump %s: %w", dumpVersion, err)
+	}
+	return client, nil
+}
+
+// serverVersion consulta as versões major e completa do servidor do datasource.
+func (pc *PgClients) serverVersion(ds entity.Datasource) (int, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, pc.Newest().Path("psql"),
+		"-h", ds.Host,
+		"-p", fmt.Sprintf("%d", ds.Port),
+		"-U", ds.Username,
+		"-d", ds.Database,
+		"-w", "-A", "-t",
+		"-c", "SELECT current_setting('server_version_num'), current_setting('server_version')",
+	)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("PGPASSWORD=%s", ds.Password),
+		fmt.Sprintf("PGSSLMODE=%s", ds.SSLMode),
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, "", fmt.Errorf("erro ao conectar ao servidor: %s\n%s", err, output)
+	}
+	return parseServerVersion(string(output))
+}
+
+// parseServerVersion lê a saída "150004|15.4 (Debian 15.4-1.pgdg110+1)". A versão major é server_version_num / 10000,
+// o que reduz as versões anteriores à 10 (ex: 90624) à major 9, atendida por qualquer cliente atual.
+func parseServerVersion(output string) (int, string, error) {
+	num, version, ok := strings.Cut(strings.TrimSpace(output), "|")
+	versionNum, err := strconv.Atoi(num)
+	if !ok || err != nil {
+		return 0, "", fmt.Errorf("versão do servidor não reconhecida: %s", output)
+	}
+	version, _, _ = strings.Cut(version, " ")
+	return versionNum / 10000, version, nil
+}
@@ -5,85 +5,225 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	notificationEntity "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
 )
 
 type PostgresBackupCommand struct {
-	backupService contract.IBackupService
-	backupRepo    contract.IBackupRepository
+	backupService         contract.IBackupService
+	physicalBackupService contract.IPhysicalBackupService
+	backupRepo            contract.IBackupRepository
+	profileRepo           contract.IBackupProfileRepository
+	scheduleRepo          contract.IScheduleRepository
+	notifier              notificationContract.INotifier
 }
 
 var _ contract.ICommand = (*PostgresBackupCommand)(nil)
 
-func NewPostgresBackupCommand(backupService contract.IBackupService, backupRepo contract.IBackupRepository) *PostgresBackupCommand {
-	return &PostgresBackupCommand{backupService, backupRepo}
+func NewPostgresBackupCommand(backupService contract.IBackupService, physicalBackupService contract.IPhysicalBackupService, backupRepo contract.IBackupRepository, profileRepo contract.IBackupProfileRepository, scheduleRepo contract.IScheduleRepository, notifier notificationContract.INotifier) *PostgresBackupCommand {
+	return &PostgresBackupCommand{backupService, physicalBackupService, backupRepo, profileRepo, scheduleRepo, notifier}
 }
 
 func (pgb *PostgresBackupCommand) Command(ds entity.Datasource, trigger entity.BackupTrigger) func() {
 	return func() {
-		currenteBackup, err := pgb.onBackupInitialized(ds, trigger)
+		pgb.Run(ds, trigger)
+	}
+}
+
+// Run implements ICommand.
+func (pgb *PostgresBackupCommand) Run(ds entity.Datasource, trigger entity.BackupTrigger) (entity.Backup, error) {
+	// O agendamento e o perfil são lidos a cada execução, para que as alterações valham sem reagendar os
+	// datasources. As opções de um agendamento substituem as do perfil.
+	var optionsErr error
+	if ds.ScheduleId != nil {
+		schedule, err := pgb.scheduleRepo.GetSchedule(*ds.ScheduleId)
 		if err != nil {
-			log.Printf("[JOB ON BACKUP INITIALIZED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-			return
+			optionsErr = fmt.Errorf("erro ao carregar o agendamento %s: %w", *ds.ScheduleId, err)
+		} else {
+			ds = schedule.Apply(ds)
 		}
-
-		log.Printf("[JOB COMMAND STARTED] Datasource: %s", ds.Database)
-		decodedDataSource, err := ds.Decode()
+	} else if ds.ProfileId != nil {
+		profile, err := pgb.profileRepo.GetBackupProfile(*ds.ProfileId)
 		if err != nil {
-			log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-			if err := pgb.onBackupFailed(currenteBackup); err != nil {
-				log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-				return
-			}
-			return
+			optionsErr = fmt.Errorf("erro ao carregar o perfil de backup %s: %w", *ds.ProfileId, err)
+		} else {
+			ds = profile.Apply(ds)
 		}
+	}
 
-		fileName := fmt.Sprintf("./backups/%s-%d.sql.gz", ds.Database, time.Now().Unix())
-		_, fileOutput, err := pgb.backupService.Backup(decodedDataSource, fileName, contract.Plain)
-		if err != nil {
-			log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-			if err := pgb.onBackupFailed(currenteBackup); err != nil {
-				log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-				return
-			}
+	// O snapshot pre-restore é sempre completo: o rollback limpa o banco antes de restaurá-lo. Em
+	// datasources com backup físico ele é lógico (custom), pois o rollback restaura com pg_restore.
+	if trigger == entity.BackupPreRestore {
+		ds.DumpFilter = entity.DumpFilter{}
+		ds.SchemaOnly = false
+		ds.IncludeGlobals = false
+		ds.ScheduleId = nil
+		if ds.BackupFormat.IsPhysical() {
+			ds.BackupFormat = entity.BackupFormatCustom
+			ds.BackupJobs = 1
 		}
+	}
+
+	currenteBackup, err := pgb.onBackupInitialized(ds, trigger)
+	if err != nil {
+		log.Printf("[JOB ON BACKUP INITIALIZED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		return entity.Backup{}, err
+	}
 
-		if err := pgb.onBackupCompleted(currenteBackup, fileOutput); err != nil {
-			log.Printf("[JOB ON BACKUP COMPLETED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
-			return
+	log.Printf("[JOB COMMAND STARTED] Datasource: %s", ds.Database)
+	if optionsErr != nil {
+		log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, optionsErr.Error())
+		if err := pgb.onBackupFailed(ds, currenteBackup, optionsErr); err != nil {
+			log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
 		}
+		return *currenteBackup, optionsErr
+	}
+	decodedDataSource, err := ds.Decode()
+	if err != nil {
+		log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		if err := pgb.onBackupFailed(ds, currenteBackup, err); err != nil {
+			log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		}
+		return *currenteBackup, err
+	}
 
-		log.Printf("[JOB COMMAND FINISHED] Datasource: %s Backup File: %s", ds.Database, fileName)
+	// As versões do servidor e do cliente ficam registradas também nos backups que falharem.
+	versions, err := pgb.backupService.TestConnection(decodedDataSource)
+	currenteBackup.SetVersions(versions)
+	if err != nil {
+		log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		if err := pgb.onBackupFailed(ds, currenteBackup, err); err != nil {
+			log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		}
+		return *currenteBackup, err
+	}
+
+	var fileName, fileOutput string
+	baseName := fmt.Sprintf("./backups/%s-%d", ds.Database, time.Now().Unix())
+	if ds.BackupFormat.IsPhysical() {
+		fileName = baseName + entity.BackupFormatPhysical.Extension()
+		_, fileOutput, err = pgb.physicalBackupService.BaseBackup(decodedDataSource, fileName)
+	} else {
+		mode := contract.ModeOf(ds.BackupFormat)
+		fileName = baseName + mode.BackupFormat().Extension()
+		_, fileOutput, err = pgb.backupService.Backup(decodedDataSource, fileName, mode)
+	}
+	// Os objetos globais acompanham o dump: sem eles, o backup não restaura os donos dos objetos.
+	if err == nil && ds.IncludeGlobals {
+		var globalsFile string
+		if _, globalsFile, err = pgb.backupService.DumpGlobals(decodedDataSource, baseName+globalsExtension); err != nil {
+			os.RemoveAll(fileOutput)
+		} else {
+			currenteBackup.GlobalsFilePath = globalsFile
+		}
+	}
+	if err != nil {
+		log.Printf("[JOB COMMAND ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		if err := pgb.onBackupFailed(ds, currenteBackup, err); err != nil {
+			log.Printf("[JOB ON BACKUP FAILED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		}
+		return *currenteBackup, err
+	}
+
+	if err := pgb.onBackupCompleted(ds, currenteBackup, fileOutput); err != nil {
+		log.Printf("[JOB ON BACKUP COMPLETED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		return *currenteBackup, err
+	}
+
+	log.Printf("[JOB COMMAND FINISHED] Datasource: %s Backup File: %s", ds.Database, fileName)
+	if trigger == entity.BackupCron || trigger == entity.BackupManual {
+		pgb.applyRetention(ds)
+	}
+	return *currenteBackup, nil
+}
+
+// Skip implements ICommand.
+func (pgb *PostgresBackupCommand) Skip(ds entity.Datasource, window entity.BlackoutWindow) (entity.Backup, error) {
+	skippedBackup := entity.NewBackup(ds.ID, entity.BackupCron)
+	skippedBackup.ScheduleId = ds.ScheduleId
+	skippedBackup.SetStartedAt()
+	skippedBackup.SetFinishedAt()
+	skippedBackup.SetSkipped(window.ID)
+	if err := pgb.backupRepo.CreateBackup(*skippedBackup); err != nil {
+		log.Printf("[JOB ON BACKUP SKIPPED ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		return entity.Backup{}, err
+	}
+
+	log.Printf("[JOB COMMAND SKIPPED] Datasource: %s, janela de bloqueio: %s", ds.Database, window.Name)
+	pgb.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventBackupSkipped, ds, skippedBackup))
+	return *skippedBackup, nil
+}
+
+// applyRetention remove os backups do datasource expirados pela retenção do perfil ou do agendamento (ver
+// Retention.Expired), com os seus arquivos, e notifica cada remoção. A retenção de um agendamento considera apenas
+// os backups gerados por ele; a do datasource, apenas os demais. Falhas são apenas registradas no log.
+func (pgb *PostgresBackupCommand) applyRetention(ds entity.Datasource) {
+	if ds.Retention.IsEmpty() {
+		return
+	}
+	backups, err := pgb.backupRepo.GetBackups(&ds.ID)
+	if err != nil {
+		log.Printf("[JOB RETENTION ERROR] Datasource: %s, Error: %s", ds.Database, err.Error())
+		return
+	}
+	backups = slices.DeleteFunc(backups, func(backup entity.Backup) bool {
+		if ds.ScheduleId == nil || backup.ScheduleId == nil {
+			return ds.ScheduleId != backup.ScheduleId
+		}
+		return *ds.ScheduleId != *backup.ScheduleId
+	})
+	for _, backup := range ds.Retention.Expired(backups, time.Now()) {
+		for _, file := range []string{backup.FilePath, backup.GlobalsFilePath} {
+			if file == "" {
+				continue
+			}
+			if err := os.RemoveAll(file); err != nil {
+				log.Printf("[JOB RETENTION ERROR] Datasource: %s, erro ao remover %s: %s", ds.Database, file, err.Error())
+			}
+		}
+		if err := pgb.backupRepo.DeleteBackup(backup.ID); err != nil {
+			log.Printf("[JOB RETENTION ERROR] Datasource: %s, erro ao remover o backup %s: %s", ds.Database, backup.ID, err.Error())
+			continue
+		}
+		log.Printf("[JOB RETENTION] Datasource: %s, backup %s removido", ds.Database, backup.ID)
+		pgb.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRetentionDeleted, ds, &backup))
 	}
 }
 
 func (pgb *PostgresBackupCommand) onBackupInitialized(ds entity.Datasource, trigger entity.BackupTrigger) (*entity.Backup, error) {
 	currenteBackup := entity.NewBackup(ds.ID, trigger)
+	currenteBackup.DumpFilter = ds.DumpFilter
+	currenteBackup.SchemaOnly = ds.SchemaOnly
+	currenteBackup.ScheduleId = ds.ScheduleId
 	currenteBackup.SetStartedAt()
 	if err := pgb.backupRepo.CreateBackup(*currenteBackup); err != nil {
 		return &entity.Backup{}, err
 	}
+	pgb.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventBackupStarted, ds, currenteBackup))
 	return currenteBackup, nil
 }
 
-func (pgb *PostgresBackupCommand) onBackupFailed(currenteBackup *entity.Backup) error {
+func (pgb *PostgresBackupCommand) onBackupFailed(ds entity.Datasource, currenteBackup *entity.Backup, cause error) error {
 	currenteBackup.SetFailed()
 	if currenteBackup.FinishedAt == nil {
 		currenteBackup.SetFinishedAt()
 	}
+	pgb.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventBackupFailed, ds, currenteBackup).WithError(cause))
 	if err := pgb.backupRepo.UpdateBackup(*currenteBackup); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (pgb *PostgresBackupCommand) onBackupCompleted(currenteBackup *entity.Backup, fileOutput string) error {
+func (pgb *PostgresBackupCommand) onBackupCompleted(ds entity.Datasource, currenteBackup *entity.Backup, fileOutput string) error {
 	fileInfo, err := os.Stat(fileOutput)
 	if err != nil {
-		if err := pgb.onBackupFailed(currenteBackup); err != nil {
+		if err := pgb.onBackupFailed(ds, currenteBackup, err); err != nil {
 			return err
 		}
 		return err
@@ -93,6 +233,11 @@ func (pgb *PostgresBackupCommand) onBackupCompleted(currenteBackup *entity.Backu
 	currenteBackup.FilePath = fileOutput
 	currenteBackup.FileSize = fileInfo.Size()
 	currenteBackup.FileOriginalName = filepath.Base(fileOutput)
+	if checksum, err := fileChecksum(fileOutput); err != nil {
+		log.Printf("[JOB ON BACKUP COMPLETED] Datasource: %s, erro ao calcular o checksum: %s", ds.Database, err.Error())
+	} else {
+		currenteBackup.Checksum = checksum
+	}
 
 	if currenteBackup.FinishedAt == nil {
 		currenteBackup.SetFinishedAt()
@@ -101,5 +246,6 @@ func (pgb *PostgresBackupCommand) onBackupCompleted(currenteBackup *entity.Backu
 	if err := pgb.backupRepo.UpdateBackup(*currenteBackup); err != nil {
 		return err
 	}
+	pgb.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventBackupCompleted, ds, currenteBackup))
 	return nil
 }
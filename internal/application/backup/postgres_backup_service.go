@@ -1,135 +1,271 @@
 package backup
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
 	"github.com/bvaledev/database-backup-management-be/internal/pkg/compression"
-	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/pgdump"
 )
 
 var (
 	timeOutInMinutes = time.Duration(15)
 )
 
-type PostgresBackupService struct{}
+// maintenanceDatabase é o banco usado para comandos que não podem ser executados no próprio banco alvo,
+// como CREATE DATABASE e DROP DATABASE.
+const maintenanceDatabase = "postgres"
+
+// globalsExtension é a extensão dos arquivos gerados por DumpGlobals.
+const globalsExtension = ".globals.sql.gz"
+
+type PostgresBackupService struct {
+	clients *PgClients
+}
 
 var _ contract.IBackupService = (*PostgresBackupService)(nil)
 
-func NewPostgresBackupService() *PostgresBackupService {
-	service := &PostgresBackupService{}
+func NewPostgresBackupService(clients *PgClients) *PostgresBackupService {
+	service := &PostgresBackupService{clients}
 	return service
 }
 
-// TestConnection verifica a conectividade com um banco de dados PostgreSQL utilizando o comando psql.
+// TestConnection verifica a conectividade com um banco de dados PostgreSQL utilizando o comando psql e
+// identifica a versão do servidor, que define os binários usados nos backups (ver PgClients.Select).
 //
 // Parâmetros:
 // - ds: informações de conexão com o banco de dados (host, porta, usuário, senha, banco, sslmode).
 //
 // Retorna:
-// - Um erro, caso a conexão falhe.
-// - nil, se a conexão for bem-sucedida.
-func (pbs *PostgresBackupService) TestConnection(ds entity.Datasource) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
-	defer cancel()
-
-	cmd := pbs.buildCommand(
-		ds,
-		ctx,
-		"psql",
-		"-h", ds.Host,
-		"-p", fmt.Sprintf("%d", ds.Port),
-		"-U", ds.Username,
-		"-d", ds.Database,
-		"-v",
-		fmt.Sprintf("sslmode=%s", ds.SSLMode),
-	)
-	_, err := cmd.CombinedOutput()
-	if err != nil {
-		return err
-	}
-
-	return nil
+// - As versões do servidor e do cliente selecionado.
+// - Um erro, caso a conexão falhe ou nenhum cliente seja compatível com o servidor.
+func (pbs *PostgresBackupService) TestConnection(ds entity.Datasource) (entity.PgVersions, error) {
+	_, versions, err := pbs.clients.ForServer(ds)
+	return versions, err
 }
 
 // Backup realiza o backup de um banco de dados PostgreSQL utilizando o utilitário pg_dump.
 //
-// Esta função sempre gera backups compactados com Gzip, com a extensão final definida conforme o tipo:
+// Esta função gera um único arquivo de backup, com a extensão final definida conforme o tipo:
 // - Plain: gera um arquivo .sql.gz com os comandos SQL brutos.
 // - Custom: gera um arquivo .backup.gz no formato customizado do PostgreSQL (ideal para pg_restore).
+// - Tar: gera um arquivo .tar.gz no formato tar do pg_dump.
+// - Directory: gera um diretório (com -j ds.BackupJobs, quando maior que 1) empacotado em um arquivo .dir.tar.
+//
+// O ds.DumpFilter é aplicado com -n/-N (schemas), -t/-T (tabelas) e --exclude-table-data.
+//
+// O ds.CompressionLevel define o nível do gzip (ou o -Z do pg_dump, no formato directory) e o ds.BackupTimeout
+// substitui o tempo máximo padrão; ambos vêm do perfil de backup do datasource.
 //
 // O backup é salvo no diretório padrão "./backups" com a extensão apropriada.
 //
 // Parâmetros:
 // - ds: informações de conexão com o banco de dados (host, porta, usuário, senha, sslmode, nome do banco).
 // - outputFile: nome base do arquivo de backup (sem extensão).
-// - format: tipo de formato interno (Plain ou Custom). O resultado será sempre compactado.
+// - format: tipo de formato interno (Plain, Custom, Tar ou Directory).
 //
 // Retorna:
 // - A saída gerada pelo comando pg_dump (string), útil para logs e debugging.
 // - Um erro, caso a execução do backup falhe ou a compactação não seja concluída com sucesso.
 func (pbs *PostgresBackupService) Backup(ds entity.Datasource, outputFile string, format contract.Mode) (string, string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	timeout := timeOutInMinutes * time.Minute
+	if ds.BackupTimeout > 0 {
+		timeout = ds.BackupTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	if err := pbs.TestConnection(ds); err != nil {
+	client, _, err := pbs.clients.ForServer(ds)
+	if err != nil {
 		return "", "", err
 	}
 
-	var dumpFormat contract.Mode
-	var tmpExt, finalExt string
-
+	var tmpExt string
 	switch format {
 	case contract.Plain:
-		dumpFormat = contract.Plain
 		tmpExt = ".sql"
-		finalExt = ".sql.gz"
 	case contract.Custom:
-		dumpFormat = contract.Custom
 		tmpExt = ".backup"
-		finalExt = ".backup.gz"
+	case contract.Tar:
+		tmpExt = ".tar"
+	case contract.Directory:
+		tmpExt = ".dir"
 	default:
 		return "", "", fmt.Errorf("formato inválido de backup: %s", format)
 	}
+	finalExt := format.BackupFormat().Extension()
 
-	fileName := utils.RemoveFileExtension(outputFile)
+	fileName := strings.TrimSuffix(outputFile, finalExt)
 
 	tmpOutput := fileName + tmpExt
 	finalOutput := fileName + finalExt
 
-	cmd := pbs.buildCommand(
-		ds,
-		ctx,
-		"pg_dump",
-		"--no-owner",
+	args := []string{
 		"-h", ds.Host,
 		"-p", fmt.Sprintf("%d", ds.Port),
 		"-U", ds.Username,
 		"-d", ds.Database,
-		"-F", string(dumpFormat),
+		"-F", string(format),
 		"-v",
 		"-f", tmpOutput,
-	)
+	}
+	// Sem os objetos globais, os donos dos objetos podem não existir no servidor de destino da restauração.
+	if !ds.IncludeGlobals {
+		args = append(args, "--no-owner")
+	}
+	if format == contract.Directory && ds.BackupJobs > 1 {
+		args = append(args, "-j", fmt.Sprintf("%d", ds.BackupJobs))
+	}
+	if format == contract.Directory && ds.CompressionLevel > 0 {
+		args = append(args, "-Z", fmt.Sprintf("%d", ds.CompressionLevel))
+	}
+	if ds.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+	args = append(args, dumpFilterArgs(ds.DumpFilter)...)
+	cmd := pbs.buildCommand(ds, ctx, client.Path("pg_dump"), args...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		os.RemoveAll(tmpOutput)
 		return "", "", fmt.Errorf("erro ao executar o backup: %s\n%s", err, string(output))
 	}
 
-	if err := compression.CompressToGzip(tmpOutput, finalOutput); err != nil {
+	// O formato directory já compacta cada tabela; o diretório é apenas empacotado em um tar.
+	if format == contract.Directory {
+		if err := compression.ArchiveDirectory(tmpOutput, finalOutput); err != nil {
+			return string(output), "", fmt.Errorf("backup realizado, mas erro ao empacotar o diretório: %w", err)
+		}
+		return string(output), finalOutput, nil
+	}
+
+	if err := compression.CompressToGzipLevel(tmpOutput, finalOutput, gzipLevel(ds.CompressionLevel)); err != nil {
 		return string(output), "", fmt.Errorf("backup realizado, mas erro ao compactar: %w", err)
 	}
 
 	return string(output), finalOutput, nil
 }
 
+// DumpGlobals implements IBackupService.
+//
+// O pg_dumpall lê as senhas dos roles em pg_authid, acessível apenas a superusuários. Sem essa permissão
+// (ex: serviços gerenciados), o dump é repetido com --no-role-passwords e os roles são restaurados sem senha.
+func (pbs *PostgresBackupService) DumpGlobals(ds entity.Datasource, outputFile string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	defer cancel()
+
+	finalOutput := strings.TrimSuffix(outputFile, globalsExtension) + globalsExtension
+	tmpOutput := strings.TrimSuffix(finalOutput, ".gz")
+
+	client, _, err := pbs.clients.ForServer(ds)
+	if err != nil {
+		return "", "", err
+	}
+
+	args := []string{
+		"--globals-only",
+		"-h", ds.Host,
+		"-p", fmt.Sprintf("%d", ds.Port),
+		"-U", ds.Username,
+		"-l", maintenanceDatabase,
+		"-v",
+		"-f", tmpOutput,
+	}
+	output, err := pbs.buildCommand(ds, ctx, client.Path("pg_dumpall"), args...).CombinedOutput()
+	if err != nil && strings.Contains(string(output), "pg_authid") {
+		log.Printf("sem permissão para ler as senhas dos roles de %s:%d, repetindo com --no-role-passwords", ds.Host, ds.Port)
+		output, err = pbs.buildCommand(ds, ctx, client.Path("pg_dumpall"), append(args, "--no-role-passwords")...).CombinedOutput()
+	}
+	if err != nil {
+		os.Remove(tmpOutput)
+		return "", "", fmt.Errorf("erro ao executar o backup dos objetos globais: %s\n%s", err, string(output))
+	}
+
+	if err := compression.CompressToGzip(tmpOutput, finalOutput); err != nil {
+		return string(output), "", fmt.Errorf("backup dos objetos globais realizado, mas erro ao compactar: %w", err)
+	}
+	return string(output), finalOutput, nil
+}
+
+// RestoreGlobals implements IBackupService.
+//
+// O arquivo é executado sem ON_ERROR_STOP no banco de manutenção: o CREATE ROLE de um role existente falha,
+// mas o ALTER ROLE seguinte ainda aplica os atributos e a senha do backup. Apenas a falha do próprio psql
+// (ex: conexão) interrompe a restauração.
+func (pbs *PostgresBackupService) RestoreGlobals(ds entity.Datasource, inputFile string) (string, error) {
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return "", fmt.Errorf("arquivo de objetos globais não encontrado: %s", inputFile)
+	}
+
+	if !strings.HasSuffix(inputFile, globalsExtension) {
+		return "", fmt.Errorf("o arquivo %s não é um backup de objetos globais (%s)", inputFile, globalsExtension)
+	}
+	tmpInput, err := compression.DecompressGzip(inputFile)
+	if err != nil {
+		return "", fmt.Errorf("erro ao descompactar %s: %w", inputFile, err)
+	}
+	defer os.Remove(tmpInput)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	defer cancel()
+
+	log.Println("Restaurando objetos globais.")
+	output, err := pbs.buildCommand(
+		ds, ctx, pbs.clients.Newest().Path("psql"),
+		"-h", ds.Host,
+		"-p", fmt.Sprintf("%d", ds.Port),
+		"-U", ds.Username,
+		"-d", maintenanceDatabase,
+		"-f", tmpInput,
+	).CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("falha na restauração dos objetos globais: %w\n%s", err, output)
+	}
+	return string(output), nil
+}
+
+// gzipLevel converte o nível de compressão do datasource, em que zero usa o padrão, no nível do gzip.
+func gzipLevel(level int) int {
+	if level == 0 {
+		return gzip.DefaultCompression
+	}
+	return level
+}
+
+// dumpFilterArgs converte o filtro do datasource nos argumentos -n/-N/-t/-T/--exclude-table-data do pg_dump.
+func dumpFilterArgs(filter entity.DumpFilter) []string {
+	args := make([]string, 0)
+	for _, schema := range filter.Schemas {
+		args = append(args, "-n", schema)
+	}
+	for _, schema := range filter.ExcludeSchemas {
+		args = append(args, "-N", schema)
+	}
+	for _, table := range filter.Tables {
+		args = append(args, "-t", table)
+	}
+	for _, table := range filter.ExcludeTables {
+		args = append(args, "-T", table)
+	}
+	for _, table := range filter.ExcludeTableData {
+		args = append(args, "--exclude-table-data", table)
+	}
+	return args
+}
+
 // ClearDatabase remove todos os schemas customizados de um banco de dados PostgreSQL,
 // recriando apenas o schema público padrão.
 //
@@ -183,7 +319,7 @@ func (pbs *PostgresBackupService) ClearDatabase(ds entity.Datasource) error {
 	cmd := pbs.buildCommand(
 		ds,
 		ctx,
-		"psql",
+		pbs.clients.Newest().Path("psql"),
 		"-h", ds.Host,
 		"-p", fmt.Sprintf("%d", ds.Port),
 		"-U", ds.Username,
@@ -202,76 +338,104 @@ func (pbs *PostgresBackupService) ClearDatabase(ds entity.Datasource) error {
 }
 
 // Restore restaura um banco de dados PostgreSQL a partir de um arquivo de backup nos formatos:
-// .sql, .sql.gz, .backup ou .backup.gz.
+// .sql, .sql.gz, .backup, .backup.gz, .tar, .tar.gz ou .dir.tar.
 //
 // O tipo de restauração é detectado automaticamente com base na extensão do arquivo:
 // - .sql           → executa o comando `psql` com o script SQL.
 // - .sql.gz        → descompacta e executa `psql` com o script SQL.
 // - .backup        → executa `pg_restore` com o formato custom do PostgreSQL.
 // - .backup.gz     → descompacta e executa `pg_restore`.
+// - .tar, .tar.gz  → descompacta, se necessário, e executa `pg_restore` com o formato tar.
+// - .dir.tar       → extrai o diretório do formato directory e executa `pg_restore`.
+//
+// Nos formatos custom e directory, ds.BackupJobs maior que 1 executa o `pg_restore` com `-j`. O `pg_restore`
+// precisa ser da versão major do servidor de destino ou mais recente e não lê arquivos de um pg_dump mais novo
+// que ele ("unsupported version in file header"); por isso é usado o cliente de versão igual ou acima da maior
+// entre as duas (dumpVersion).
+//
+// ⚠️ Somente arquivos com as extensões .sql.gz, .backup.gz e .tar.gz são aceitos como válidos para restauração compactada.
 //
-// ⚠️ Somente arquivos com as extensões .sql.gz e .backup.gz são aceitos como válidos para restauração compactada.
+// Antes da restauração, o banco de dados é limpo (todos os schemas são removidos, exceto os padrões),
+// a menos que scope.SkipClear seja informado. A limpeza acontece apenas depois que o arquivo foi preparado e os
+// filtros da restauração seletiva foram validados contra a TOC do backup.
 //
-// Antes da restauração, o banco de dados é limpo (todos os schemas são removidos, exceto os padrões).
-func (pbs *PostgresBackupService) Restore(ds entity.Datasource, inputFile string) (string, error) {
+// Restauração seletiva (formatos custom, tar e directory):
+// - Schemas/ExcludeSchemas → `pg_restore -n`/`-N`.
+// - Tables/ExcludeTables   → lista `pg_restore -L` gerada a partir da TOC. Assim como no `-t`, a inclusão
+// de tabelas restaura apenas a definição e os dados; a exclusão remove também constraints, defaults,
+// triggers, índices e chaves estrangeiras que dependem das tabelas excluídas.
+// - DataOnly/SchemaOnly    → `--data-only`/`--schema-only`.
+func (pbs *PostgresBackupService) Restore(ds entity.Datasource, inputFile, dumpVersion string, scope entity.RestoreScope) (string, error) {
 	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
 		return "", fmt.Errorf("arquivo de backup não encontrado: %s", inputFile)
 	}
 
 	// Detecta tipo de backup
-	var usePgRestore bool
-	var isGzipped bool
-
-	switch {
-	case strings.HasSuffix(inputFile, ".sql"):
-		usePgRestore = false
-		isGzipped = false
-	case strings.HasSuffix(inputFile, ".sql.gz"):
-		isGzipped = true
-		usePgRestore = false
-	case strings.HasSuffix(inputFile, ".backup"):
-		usePgRestore = true
-		isGzipped = false
-	case strings.HasSuffix(inputFile, ".backup.gz"):
-		isGzipped = true
-		usePgRestore = true
-
-	default:
-		return "", fmt.Errorf("extensão do arquivo não reconhecida: %s", inputFile)
+	format, isGzipped, err := detectBackupFormat(inputFile)
+	if err != nil {
+		return "", err
 	}
-
-	if err := pbs.ClearDatabase(ds); err != nil {
-		return "", fmt.Errorf("falha ao limpar o banco de dados: %w", err)
+	if scope.IsSelective() && !format.UsesPgRestore() {
+		return "", entity.ErrSelectiveRestoreUnsupported
+	}
+	// O pg_restore atende ao servidor de destino e ao pg_dump do backup; o psql do formato plain apenas executa o script.
+	var client PgClient
+	if format.UsesPgRestore() {
+		if client, err = pbs.clients.ForRestore(ds, dumpVersion); err != nil {
+			return "", err
+		}
 	}
 
+	// O arquivo é preparado antes da limpeza: um arquivo corrompido ou a falta de espaço em disco não podem deixar
+	// o banco de destino vazio.
 	originalInput := inputFile
-	if isGzipped {
-		tmp, err := compression.DecompressGzip(inputFile)
-		if err != nil {
-			return "", fmt.Errorf("erro ao descompactar %s: %w", inputFile, err)
-		}
-		inputFile = tmp
-		defer os.Remove(tmp)
+	inputFile, cleanupInput, err := prepareArchive(inputFile, format, isGzipped)
+	if err != nil {
+		return "", err
 	}
+	defer cleanupInput()
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
 	defer cancel()
 
+	// Os argumentos da restauração seletiva também são montados antes da limpeza: uma falha no `pg_restore --list`
+	// ou um schema/tabela que não existe no backup não podem deixar o banco de destino vazio.
+	var scopeArgs []string
+	if format.UsesPgRestore() {
+		args, cleanup, err := pbs.scopeArgs(ctx, inputFile, scope)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		scopeArgs = args
+	}
+
+	if !scope.SkipClear {
+		if err := pbs.ClearDatabase(ds); err != nil {
+			return "", fmt.Errorf("falha ao limpar o banco de dados: %w", err)
+		}
+	}
+
 	var cmd *exec.Cmd
-	if usePgRestore {
-		cmd = pbs.buildCommand(
-			ds, ctx, "pg_restore",
-			"--no-owner",
+	if format.UsesPgRestore() {
+		args := []string{
 			"-h", ds.Host,
 			"-p", fmt.Sprintf("%d", ds.Port),
 			"-U", ds.Username,
 			"-d", ds.Database,
 			"-v",
-			inputFile,
-		)
+		}
+		if !scope.KeepOwners {
+			args = append(args, "--no-owner")
+		}
+		if format.SupportsJobs() && ds.BackupJobs > 1 {
+			args = append(args, "-j", fmt.Sprintf("%d", ds.BackupJobs))
+		}
+		args = append(append(args, scopeArgs...), inputFile)
+		cmd = pbs.buildCommand(ds, ctx, client.Path("pg_restore"), args...)
 	} else {
 		cmd = pbs.buildCommand(
-			ds, ctx, "psql",
+			ds, ctx, pbs.clients.Newest().Path("psql"),
 			"-h", ds.Host,
 			"-p", fmt.Sprintf("%d", ds.Port),
 			"-U", ds.Username,
@@ -293,6 +457,505 @@ func (pbs *PostgresBackupService) Restore(ds entity.Datasource, inputFile string
 	return string(output), nil
 }
 
+// ListContents retorna a tabela de conteúdo de um backup nos formatos custom, tar ou directory,
+// obtida com `pg_restore --list`.
+//
+// Retorna:
+// - As entradas da TOC, na ordem de restauração.
+// - entity.ErrSelectiveRestoreUnsupported para backups no formato plain.
+func (pbs *PostgresBackupService) ListContents(inputFile string) (pgdump.TOC, error) {
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("arquivo de backup não encontrado: %s", inputFile)
+	}
+	format, isGzipped, err := detectBackupFormat(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	if !format.UsesPgRestore() {
+		return nil, entity.ErrSelectiveRestoreUnsupported
+	}
+	inputFile, cleanup, err := prepareArchive(inputFile, format, isGzipped)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	defer cancel()
+	return pbs.listTOC(ctx, inputFile)
+}
+
+// Contents calcula o conteúdo de um backup: schemas, tabelas (com o tamanho aproximado dos dados),
+// views, funções, índices e sequences.
+//
+// - Formatos custom, tar e directory → objetos do `pg_restore --list` e tamanhos do script de dados (`pg_restore --data-only -f -`).
+// - Formato plain                    → leitura em fluxo do script .sql/.sql.gz, pelos comentários que o pg_dump escreve antes de cada objeto.
+//
+// Retorna o conteúdo sem BackupId, que deve ser preenchido por quem chama.
+func (pbs *PostgresBackupService) Contents(inputFile string) (entity.BackupContents, error) {
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return entity.BackupContents{}, fmt.Errorf("arquivo de backup não encontrado: %s", inputFile)
+	}
+	format, isGzipped, err := detectBackupFormat(inputFile)
+	if err != nil {
+		return entity.BackupContents{}, err
+	}
+
+	if !format.UsesPgRestore() {
+		objects, err := plainObjects(inputFile, isGzipped)
+		if err != nil {
+			return entity.BackupContents{}, fmt.Errorf("erro ao ler o script do backup: %w", err)
+		}
+		return *entity.NewBackupContents("", entity.BackupFormatPlain, contentObjects(objects)), nil
+	}
+
+	inputFile, cleanup, err := prepareArchive(inputFile, format, isGzipped)
+	if err != nil {
+		return entity.BackupContents{}, err
+	}
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	defer cancel()
+
+	toc, err := pbs.listTOC(ctx, inputFile)
+	if err != nil {
+		return entity.BackupContents{}, err
+	}
+	data, err := pbs.scriptObjects(ctx, "--data-only", "-f", "-", inputFile)
+	if err != nil {
+		return entity.BackupContents{}, err
+	}
+	dataSizes := make(map[string]int64, len(data))
+	for _, object := range data {
+		dataSizes[object.Type+" "+object.Schema+"."+object.Name] += object.Size
+	}
+
+	objects := make([]entity.ContentObject, 0, len(toc))
+	for _, entry := range toc {
+		objects = append(objects, entity.ContentObject{
+			Type:     entry.Type,
+			Schema:   entry.Schema,
+			Name:     entry.Name,
+			Owner:    entry.Owner,
+			DataSize: dataSizes[entry.Type+" "+entry.Schema+"."+entry.Name],
+		})
+	}
+	return *entity.NewBackupContents("", format, objects), nil
+}
+
+// scriptObjects executa o pg_restore gerando o script na saída padrão e o percorre em fluxo.
+func (pbs *PostgresBackupService) scriptObjects(ctx context.Context, args ...string) ([]pgdump.Object, error) {
+	cmd := pbs.buildCommand(entity.Datasource{}, ctx, pbs.clients.Newest().Path("pg_restore"), args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("erro ao ler o conteúdo do backup: %w", err)
+	}
+
+	objects, parseErr := pgdump.ParseObjects(stdout)
+	if parseErr != nil {
+		io.Copy(io.Discard, stdout)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("erro ao ler o conteúdo do backup: %w\n%s", err, stderr.String())
+	}
+	return objects, parseErr
+}
+
+// plainObjects percorre um backup no formato plain, descompactando-o em fluxo quando necessário.
+func plainObjects(inputFile string, isGzipped bool) ([]pgdump.Object, error) {
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if isGzipped {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+	return pgdump.ParseObjects(reader)
+}
+
+// OpenTableData abre em fluxo os dados de uma tabela do backup, sem restaurá-lo em um banco.
+//
+// - Formatos custom, tar e directory → `pg_restore --data-only -t <tabela> -f -`, lido pela saída padrão.
+// - Formato plain                    → leitura do script .sql/.sql.gz até o COPY da tabela.
+//
+// Parâmetros:
+// - inputFile: arquivo de backup.
+// - table: nome da tabela, opcionalmente qualificado pelo schema ("schema.tabela").
+//
+// Retorna:
+// - O leitor das linhas da tabela, que deve ser fechado por quem chama.
+// - pgdump.ErrTableNotFound se o backup não contém os dados da tabela.
+func (pbs *PostgresBackupService) OpenTableData(inputFile string, table string) (*pgdump.TableReader, error) {
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("arquivo de backup não encontrado: %s", inputFile)
+	}
+	format, isGzipped, err := detectBackupFormat(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	schema, name := pgdump.SplitTableName(table)
+
+	if !format.UsesPgRestore() {
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return nil, err
+		}
+		var reader io.Reader = file
+		if isGzipped {
+			gzipReader, err := gzip.NewReader(file)
+			if err != nil {
+				file.Close()
+				return nil, err
+			}
+			reader = gzipReader
+		}
+		tableReader, err := pgdump.NewTableReader(reader, file, schema, name)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return tableReader, nil
+	}
+
+	inputFile, cleanup, err := prepareArchive(inputFile, format, isGzipped)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
+	args := []string{"--data-only", "-t", name}
+	if schema != "" {
+		args = append(args, "-n", schema)
+	}
+	cmd := pbs.buildCommand(entity.Datasource{}, ctx, pbs.clients.Newest().Path("pg_restore"), append(args, "-f", "-", inputFile)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		cleanup()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		cleanup()
+		return nil, fmt.Errorf("erro ao ler os dados da tabela: %w", err)
+	}
+
+	// Fechar antes do fim dos dados interrompe o pg_restore.
+	closer := closerFunc(func() error {
+		cancel()
+		cmd.Wait()
+		cleanup()
+		return nil
+	})
+	tableReader, err := pgdump.NewTableReader(stdout, closer, schema, name)
+	if err != nil {
+		// Sem a tabela, a saída já foi lida até o fim; o erro do pg_restore, se houver, é mais informativo.
+		if errors.Is(err, pgdump.ErrTableNotFound) {
+			if waitErr := cmd.Wait(); waitErr != nil {
+				err = fmt.Errorf("erro ao ler os dados da tabela: %w\n%s", waitErr, stderr.String())
+			}
+		}
+		closer.Close()
+		return nil, err
+	}
+	return tableReader, nil
+}
+
+// closerFunc adapta uma função para io.Closer.
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// contentObjects converte os objetos do script, usando o tamanho da seção como tamanho dos dados em TABLE DATA.
+func contentObjects(objects []pgdump.Object) []entity.ContentObject {
+	contents := make([]entity.ContentObject, 0, len(objects))
+	for _, object := range objects {
+		content := entity.ContentObject{
+			Type:   object.Type,
+			Schema: object.Schema,
+			Name:   object.Name,
+			Owner:  object.Owner,
+		}
+		if object.Type == pgdump.TypeTableData {
+			content.DataSize = object.Size
+		}
+		contents = append(contents, content)
+	}
+	return contents
+}
+
+// detectBackupFormat identifica pela extensão o formato do backup e se ele está compactado com gzip.
+// Backups físicos (.base.tar) retornam entity.ErrPhysicalBackup.
+func detectBackupFormat(inputFile string) (entity.BackupFormat, bool, error) {
+	switch {
+	case strings.HasSuffix(inputFile, ".sql"):
+		return entity.BackupFormatPlain, false, nil
+	case strings.HasSuffix(inputFile, ".sql.gz"):
+		return entity.BackupFormatPlain, true, nil
+	case strings.HasSuffix(inputFile, ".backup"):
+		return entity.BackupFormatCustom, false, nil
+	case strings.HasSuffix(inputFile, ".backup.gz"):
+		return entity.BackupFormatCustom, true, nil
+	case strings.HasSuffix(inputFile, entity.BackupFormatPhysical.Extension()):
+		return "", false, entity.ErrPhysicalBackup
+	case strings.HasSuffix(inputFile, ".dir.tar"):
+		return entity.BackupFormatDirectory, false, nil
+	case strings.HasSuffix(inputFile, ".tar"):
+		return entity.BackupFormatTar, false, nil
+	case strings.HasSuffix(inputFile, ".tar.gz"):
+		return entity.BackupFormatTar, true, nil
+	default:
+		return "", false, fmt.Errorf("extensão do arquivo não reconhecida: %s", inputFile)
+	}
+}
+
+// prepareArchive deixa o backup pronto para o pg_restore: descompacta o gzip ou extrai o diretório do
+// formato directory. A função de limpeza retornada remove os arquivos temporários.
+func prepareArchive(inputFile string, format entity.BackupFormat, isGzipped bool) (string, func(), error) {
+	if isGzipped {
+		tmp, err := compression.DecompressGzip(inputFile)
+		if err != nil {
+			return "", func() {}, fmt.Errorf("erro ao descompactar %s: %w", inputFile, err)
+		}
+		return tmp, func() { os.Remove(tmp) }, nil
+	}
+	if format == entity.BackupFormatDirectory {
+		dir, err := compression.ExtractArchive(inputFile)
+		if err != nil {
+			return "", func() {}, fmt.Errorf("erro ao extrair %s: %w", inputFile, err)
+		}
+		return dir, func() { os.RemoveAll(dir) }, nil
+	}
+	return inputFile, func() {}, nil
+}
+
+// listTOC executa `pg_restore --list` em um backup já preparado por prepareArchive.
+func (pbs *PostgresBackupService) listTOC(ctx context.Context, inputFile string) (pgdump.TOC, error) {
+	cmd := pbs.buildCommand(entity.Datasource{}, ctx, pbs.clients.Newest().Path("pg_restore"), "--list", inputFile)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar o conteúdo do backup: %w", err)
+	}
+	return pgdump.ParseList(bytes.NewReader(output))
+}
+
+// scopeArgs monta os argumentos do pg_restore para uma restauração seletiva. Filtros de tabelas
+// geram uma lista temporária para `-L`, removida pela função de limpeza retornada.
+func (pbs *PostgresBackupService) scopeArgs(ctx context.Context, inputFile string, scope entity.RestoreScope) ([]string, func(), error) {
+	args := make([]string, 0)
+	cleanup := func() {}
+	if scope.DataOnly {
+		args = append(args, "--data-only")
+	}
+	if scope.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+
+	if len(scope.Schemas) == 0 && len(scope.ExcludeSchemas) == 0 && len(scope.Tables) == 0 && len(scope.ExcludeTables) == 0 {
+		return args, cleanup, nil
+	}
+
+	toc, err := pbs.listTOC(ctx, inputFile)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	if err := checkScopeObjects(toc, scope); err != nil {
+		return nil, cleanup, err
+	}
+	if len(scope.Tables) == 0 && len(scope.ExcludeTables) == 0 {
+		for _, schema := range scope.Schemas {
+			args = append(args, "-n", schema)
+		}
+		for _, schema := range scope.ExcludeSchemas {
+			args = append(args, "-N", schema)
+		}
+		return args, cleanup, nil
+	}
+
+	references := make(map[int][]string)
+	if len(scope.ExcludeTables) > 0 {
+		if references, err = pbs.tableReferences(ctx, inputFile, toc); err != nil {
+			return nil, cleanup, err
+		}
+	}
+
+	listFile, err := os.CreateTemp("", "restore-*.list")
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("erro ao criar a lista de restauração: %w", err)
+	}
+	cleanup = func() { os.Remove(listFile.Name()) }
+	defer listFile.Close()
+
+	keep := func(entry pgdump.Entry) bool {
+		return selectEntry(entry, scope, references[entry.ID])
+	}
+	if err := toc.WriteList(listFile, keep); err != nil {
+		return nil, cleanup, fmt.Errorf("erro ao criar a lista de restauração: %w", err)
+	}
+	return append(args, "-L", listFile.Name()), cleanup, nil
+}
+
+// tableReferences identifica as tabelas das quais os índices e chaves estrangeiras do backup dependem,
+// a partir do SQL gerado pelo pg_restore apenas para essas entradas. O modo verboso (-v) inclui no script
+// os comentários "-- TOC entry <id>", que ligam cada comando à sua entrada.
+func (pbs *PostgresBackupService) tableReferences(ctx context.Context, inputFile string, toc pgdump.TOC) (map[int][]string, error) {
+	listFile, err := os.CreateTemp("", "references-*.list")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar a lista de dependências: %w", err)
+	}
+	defer os.Remove(listFile.Name())
+	defer listFile.Close()
+
+	err = toc.WriteList(listFile, func(entry pgdump.Entry) bool {
+		return entry.Type == pgdump.TypeIndex || entry.Type == pgdump.TypeFKConstraint
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar a lista de dependências: %w", err)
+	}
+
+	cmd := pbs.buildCommand(entity.Datasource{}, ctx, pbs.clients.Newest().Path("pg_restore"), "-v", "-f", "-", "-L", listFile.Name(), inputFile)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler as dependências do backup: %w", err)
+	}
+	statements, err := pgdump.SplitScript(bytes.NewReader(output))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler as dependências do backup: %w", err)
+	}
+
+	references := make(map[int][]string, len(statements))
+	for id, statement := range statements {
+		references[id] = pgdump.ReferencedTables(statement)
+	}
+	return references, nil
+}
+
+// sessionEntries são entradas da TOC que apenas configuram a sessão e são mantidas em qualquer seleção.
+var sessionEntries = map[string]bool{"ENCODING": true, "STDSTRINGS": true, "SEARCHPATH": true}
+
+// selectEntry decide se uma entrada da TOC é restaurada. references são as tabelas ("schema.tabela")
+// das quais a entrada depende, para índices e chaves estrangeiras.
+func selectEntry(entry pgdump.Entry, scope entity.RestoreScope, references []string) bool {
+	if sessionEntries[entry.Type] {
+		return true
+	}
+	schema := entry.Schema
+	if entry.Type == pgdump.TypeSchema {
+		schema = entry.Name
+	}
+	if len(scope.Schemas) > 0 && !slices.Contains(scope.Schemas, schema) {
+		return false
+	}
+	if slices.Contains(scope.ExcludeSchemas, schema) {
+		return false
+	}
+
+	if len(scope.Tables) > 0 {
+		return (entry.Type == pgdump.TypeTable || entry.Type == pgdump.TypeTableData) &&
+			matchesTable(scope.Tables, entry.Schema, entry.Name)
+	}
+	if table := entry.Table(); table != "" && matchesTable(scope.ExcludeTables, entry.Schema, table) {
+		return false
+	}
+	for _, reference := range references {
+		referenceSchema, referenceTable := pgdump.SplitTableName(reference)
+		if matchesTable(scope.ExcludeTables, referenceSchema, referenceTable) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkScopeObjects verifica se os schemas e tabelas da restauração seletiva existem na TOC do backup. Sem essa
+// verificação, um nome errado não restauraria nada em um banco já limpo.
+func checkScopeObjects(toc pgdump.TOC, scope entity.RestoreScope) error {
+	missing := make([]string, 0)
+	for _, schema := range append(append([]string{}, scope.Schemas...), scope.ExcludeSchemas...) {
+		if !toc.HasSchema(schema) {
+			missing = append(missing, schema)
+		}
+	}
+	for _, table := range append(append([]string{}, scope.Tables...), scope.ExcludeTables...) {
+		if !toc.HasTable(pgdump.SplitTableName(table)) {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: não encontrados no backup: %s", entity.ErrInvalidRestoreOptions, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// matchesTable indica se a tabela está na lista de nomes ("schema.tabela" ou "tabela").
+func matchesTable(names []string, schema, table string) bool {
+	for _, name := range names {
+		nameSchema, nameTable := pgdump.SplitTableName(name)
+		if nameTable == table && (nameSchema == "" || nameSchema == schema) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListDatabases implements IBackupService.
+//
+// A consulta retorna um único JSON, o que evita interpretar a saída tabular do psql com nomes que contenham
+// separadores. O tamanho só é calculado nos bancos com permissão de CONNECT, pois pg_database_size falha nos demais.
+func (pbs *PostgresBackupService) ListDatabases(ds entity.Datasource) ([]entity.ServerDatabase, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	query := `SELECT coalesce(json_agg(json_build_object(
+			'name', datname,
+			'size_bytes', CASE WHEN has_database_privilege(oid, 'CONNECT') THEN pg_database_size(oid) END
+		) ORDER BY datname), '[]')
+		FROM pg_database
+		WHERE NOT datistemplate AND datallowconn`
+	cmd := pbs.buildCommand(
+		ds,
+		ctx,
+		pbs.clients.Newest().Path("psql"),
+		"-h", ds.Host,
+		"-p", fmt.Sprintf("%d", ds.Port),
+		"-U", ds.Username,
+		"-d", ds.Database,
+		"-w", "-A", "-t",
+		"-v", "ON_ERROR_STOP=1",
+		"-c", query,
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("erro ao listar os bancos do servidor: %s\n%s", err, exitErr.Stderr)
+		}
+		return nil, fmt.Errorf("erro ao listar os bancos do servidor: %w", err)
+	}
+
+	var databases []entity.ServerDatabase
+	if err := json.Unmarshal(output, &databases); err != nil {
+		return nil, fmt.Errorf("resposta inválida ao listar os bancos do servidor: %w", err)
+	}
+	return databases, nil
+}
+
 // CreateDatabase cria um novo banco de dados PostgreSQL utilizando o comando psql.
 //
 // Este método conecta-se ao servidor PostgreSQL e executa um comando SQL para criar o banco de dados informado.
@@ -311,16 +974,17 @@ func (pbs *PostgresBackupService) CreateDatabase(ds entity.Datasource) error {
 	cmd := pbs.buildCommand(
 		ds,
 		ctx,
-		"psql",
+		pbs.clients.Newest().Path("psql"),
 		"-h", ds.Host,
 		"-p", fmt.Sprintf("%d", ds.Port),
 		"-U", ds.Username,
-		"-c", fmt.Sprintf("CREATE DATABASE %s;", ds.Database),
-		fmt.Sprintf("sslmode=%s", ds.SSLMode),
+		"-d", maintenanceDatabase,
+		"-v", "ON_ERROR_STOP=1",
+		"-c", fmt.Sprintf("CREATE DATABASE %s;", quoteIdentifier(ds.Database)),
 	)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("erro ao remover o banco de dados: %s\n%s", err, output)
+		return fmt.Errorf("erro ao criar o banco de dados: %s\n%s", err, output)
 	}
 	return nil
 }
@@ -341,19 +1005,23 @@ func (pbs *PostgresBackupService) DropDatabase(ds entity.Datasource) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOutInMinutes*time.Minute)
 	defer cancel()
 
+	// Encerra as conexões abertas no banco antes de removê-lo. Cada -c é executado em uma
+	// transação própria, o que é exigido pelo DROP DATABASE.
 	cmd := pbs.buildCommand(
 		ds,
 		ctx,
-		"psql",
+		pbs.clients.Newest().Path("psql"),
 		"-h", ds.Host,
 		"-p", fmt.Sprintf("%d", ds.Port),
 		"-U", ds.Username,
-		"-c", fmt.Sprintf("DROP DATABASE %s;", ds.Database),
-		fmt.Sprintf("sslmode=%s", ds.SSLMode),
+		"-d", maintenanceDatabase,
+		"-v", "ON_ERROR_STOP=1",
+		"-c", fmt.Sprintf("SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = %s AND pid <> pg_backend_pid();", quoteLiteral(ds.Database)),
+		"-c", fmt.Sprintf("DROP DATABASE IF EXISTS %s;", quoteIdentifier(ds.Database)),
 	)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("erro ao criar o banco de dados: %s\n%s", err, output)
+		return fmt.Errorf("erro ao remover o banco de dados: %s\n%s", err, output)
 	}
 	return nil
 }
@@ -366,7 +1034,7 @@ func (pbs *PostgresBackupService) DropDatabase(ds entity.Datasource) error {
 // Parâmetros:
 // - ds: informações de conexão com o banco de dados (host, porta, usuário, senha, sslmode).
 // - ctx: contexto que permite controle de timeout e cancelamento da execução.
-// - executable: nome do comando a ser executado (ex: "psql", "pg_dump", "pg_restore").
+// - executable: caminho do comando a ser executado, obtido com PgClient.Path (ex: "psql", "pg_dump", "pg_restore").
 // - args: argumentos adicionais para o comando.
 //
 // Retorna:
@@ -379,3 +1047,13 @@ func (*PostgresBackupService) buildCommand(ds entity.Datasource, ctx context.Con
 	)
 	return cmd
 }
+
+// quoteIdentifier escapa um identificador SQL (ex: nome de banco) para uso seguro em comandos.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteLiteral escapa um valor textual SQL para uso seguro em comandos.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,284 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/compression"
+)
+
+// PhysicalBackupConfig define os limites e o destino dos backups físicos.
+type PhysicalBackupConfig struct {
+	// DataDirectoryRoot é o diretório onde as restaurações no modo data_directory são criadas.
+	DataDirectoryRoot string
+	// Timeout é o tempo máximo do pg_basebackup, maior que o dos dumps lógicos por copiar o cluster inteiro.
+	Timeout time.Duration
+	// WalArchiveRoot é o diretório dos arquivos de WAL recebidos do archive_command, um subdiretório por datasource.
+	WalArchiveRoot string
+}
+
+// PhysicalBackupConfigFromEnv lê PHYSICAL_RESTORE_DIR (padrão ./restores), PHYSICAL_BACKUP_TIMEOUT (padrão 12h)
+// e WAL_ARCHIVE_DIR (padrão ./wal).
+func PhysicalBackupConfigFromEnv() PhysicalBackupConfig {
+	config := PhysicalBackupConfig{
+		DataDirectoryRoot: os.Getenv("PHYSICAL_RESTORE_DIR"),
+		Timeout:           12 * time.Hour,
+		WalArchiveRoot:    os.Getenv("WAL_ARCHIVE_DIR"),
+	}
+	if config.DataDirectoryRoot == "" {
+		config.DataDirectoryRoot = "./restores"
+	}
+	if config.WalArchiveRoot == "" {
+		config.WalArchiveRoot = "./wal"
+	}
+	if value := os.Getenv("PHYSICAL_BACKUP_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			log.Printf("PHYSICAL_BACKUP_TIMEOUT inválido (%q), usando %s", value, config.Timeout)
+		} else {
+			config.Timeout = timeout
+		}
+	}
+	return config
+}
+
+// WalDirectory retorna o diretório dos arquivos de WAL arquivados do datasource.
+func (c PhysicalBackupConfig) WalDirectory(datasourceId string) string {
+	return filepath.Join(c.WalArchiveRoot, datasourceId)
+}
+
+// Arquivos gerados pelo pg_basebackup no formato tar com -z e -X stream.
+const (
+	baseArchive       = "base.tar.gz"
+	walArchive        = "pg_wal.tar.gz"
+	backupManifest    = "backup_manifest"
+	walDirectory      = "pg_wal"
+	dataDirectoryPerm = 0700
+)
+
+type PostgresPhysicalBackupService struct {
+	config  PhysicalBackupConfig
+	clients *PgClients
+}
+
+var _ contract.IPhysicalBackupService = (*PostgresPhysicalBackupService)(nil)
+
+func NewPostgresPhysicalBackupService(config PhysicalBackupConfig, clients *PgClients) *PostgresPhysicalBackupService {
+	return &PostgresPhysicalBackupService{config, clients}
+}
+
+// BaseBackup realiza o backup físico do servidor com o pg_basebackup, no formato tar, compactado (-z) e
+// com os WAL gerados durante a cópia (-X stream), de modo que o backup seja consistente por si só.
+//
+// O pg_basebackup grava base.tar.gz, pg_wal.tar.gz e backup_manifest em um diretório temporário, que é
+// empacotado em um único arquivo .base.tar. O checkpoint é imediato (-c fast) para a cópia começar sem
+// esperar o próximo checkpoint agendado. O nível de compressão (-Z) e o tempo máximo do perfil de backup do
+// datasource substituem os padrões.
+//
+// Parâmetros:
+// - ds: informações de conexão com o servidor; o banco é ignorado, pois o backup inclui o cluster inteiro.
+// - outputFile: arquivo de destino, com a extensão .base.tar.
+//
+// Retorna:
+// - A saída gerada pelo comando pg_basebackup.
+// - Arquivo de backup gerado.
+// - Um erro, caso a execução do backup falhe ou o empacotamento não seja concluído.
+func (ppbs *PostgresPhysicalBackupService) BaseBackup(ds entity.Datasource, outputFile string) (string, string, error) {
+	timeout := ppbs.config.Timeout
+	if ds.BackupTimeout > 0 {
+		timeout = ds.BackupTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, _, err := ppbs.clients.ForServer(ds)
+	if err != nil {
+		return "", "", err
+	}
+
+	finalOutput := strings.TrimSuffix(outputFile, entity.BackupFormatPhysical.Extension()) + entity.BackupFormatPhysical.Extension()
+	tmpOutput := strings.TrimSuffix(finalOutput, ".tar")
+
+	compress := []string{"-z"}
+	if ds.CompressionLevel > 0 {
+		compress = []string{"-Z", fmt.Sprintf("%d", ds.CompressionLevel)}
+	}
+	args := append([]string{
+		"-h", ds.Host,
+		"-p", fmt.Sprintf("%d", ds.Port),
+		"-U", ds.Username,
+		"-w",
+		"-D", tmpOutput,
+		"-F", "t",
+	}, compress...)
+	args = append(args,
+		"-X", "stream",
+		"-c", "fast",
+		"-l", filepath.Base(tmpOutput),
+		"-v",
+	)
+	cmd := ppbs.buildCommand(ds, ctx, client.Path("pg_basebackup"), args...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		os.RemoveAll(tmpOutput)
+		return "", "", fmt.Errorf("erro ao executar o backup físico: %s\n%s", err, string(output))
+	}
+
+	if err := compression.ArchiveDirectory(tmpOutput, finalOutput); err != nil {
+		return string(output), "", fmt.Errorf("backup físico realizado, mas erro ao empacotar o diretório: %w", err)
+	}
+
+	return string(output), finalOutput, nil
+}
+
+// RestoreDataDirectory extrai um backup físico em <DataDirectoryRoot>/<name>: base.tar.gz na raiz do
+// diretório e pg_wal.tar.gz em pg_wal. Como o backup contém o backup_label, o servidor iniciado sobre o
+// diretório aplica os WAL incluídos e fica consistente.
+//
+// Backups com tablespaces fora do diretório de dados (um <oid>.tar.gz por tablespace) não são suportados.
+//
+// O diretório criado não é removido automaticamente, nem em caso de falha após o início da extração,
+// para permitir a investigação.
+func (ppbs *PostgresPhysicalBackupService) RestoreDataDirectory(inputFile string, name string) (string, string, error) {
+	if !strings.HasSuffix(inputFile, entity.BackupFormatPhysical.Extension()) {
+		return "", "", fmt.Errorf("o arquivo %s não é um backup físico (%s)", inputFile, entity.BackupFormatPhysical.Extension())
+	}
+
+	if err := os.MkdirAll(ppbs.config.DataDirectoryRoot, 0755); err != nil {
+		return "", "", fmt.Errorf("erro ao criar o diretório de restaurações: %w", err)
+	}
+	dataDir, err := filepath.Abs(filepath.Join(ppbs.config.DataDirectoryRoot, name))
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.Mkdir(dataDir, dataDirectoryPerm); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", "", fmt.Errorf("%w: o diretório %s já existe", entity.ErrInvalidRestoreOptions, dataDir)
+		}
+		return "", "", fmt.Errorf("erro ao criar o diretório de dados: %w", err)
+	}
+
+	extracted, err := compression.ExtractArchive(inputFile)
+	if err != nil {
+		return dataDir, "", fmt.Errorf("erro ao extrair %s: %w", inputFile, err)
+	}
+	defer os.RemoveAll(extracted)
+
+	entries, err := os.ReadDir(extracted)
+	if err != nil {
+		return dataDir, "", err
+	}
+	hasBase := false
+	for _, entry := range entries {
+		switch entry.Name() {
+		case baseArchive:
+			hasBase = true
+		case walArchive, backupManifest:
+		default:
+			return dataDir, "", fmt.Errorf("o backup contém %s: tablespaces fora do diretório de dados não são suportados", entry.Name())
+		}
+	}
+	if !hasBase {
+		return dataDir, "", fmt.Errorf("o backup não contém %s", baseArchive)
+	}
+
+	if err := compression.ExtractTarGz(filepath.Join(extracted, baseArchive), dataDir); err != nil {
+		return dataDir, "", fmt.Errorf("erro ao extrair %s: %w", baseArchive, err)
+	}
+	walSegments := "não incluídos no backup"
+	if _, err := os.Stat(filepath.Join(extracted, walArchive)); err == nil {
+		walDir := filepath.Join(dataDir, walDirectory)
+		if err := os.MkdirAll(walDir, dataDirectoryPerm); err != nil {
+			return dataDir, "", err
+		}
+		if err := compression.ExtractTarGz(filepath.Join(extracted, walArchive), walDir); err != nil {
+			return dataDir, "", fmt.Errorf("erro ao extrair %s: %w", walArchive, err)
+		}
+		walSegments = fmt.Sprintf("extraídos em %s", walDir)
+	}
+
+	// O cluster restaurado herda o archive_command da origem e, ao ser promovido, enviaria os seus WAL para o
+	// arquivo do datasource de origem.
+	if err := appendAutoConf(dataDir, "archive_mode = 'off'"); err != nil {
+		return dataDir, "", err
+	}
+
+	// O PostgreSQL recusa iniciar com permissões mais abertas que 0700 (ou 0750) no diretório de dados.
+	if err := os.Chmod(dataDir, dataDirectoryPerm); err != nil {
+		return dataDir, "", err
+	}
+
+	output := fmt.Sprintf("diretório de dados restaurado em %s\nWAL: %s\npara iniciar: pg_ctl -D %s -o \"-p <porta>\" start", dataDir, walSegments, dataDir)
+	return dataDir, output, nil
+}
+
+// ConfigureRecovery prepara um diretório de dados restaurado de um backup físico para a recuperação até um
+// ponto no tempo: cria o recovery.signal e grava no postgresql.auto.conf o restore_command, que lê os WAL
+// arquivados do datasource, o alvo (recovery_target_time ou recovery_target_lsn) e a promoção ao alcançá-lo.
+//
+// A recuperação acontece ao iniciar o servidor sobre o diretório; se os WAL arquivados terminarem antes do
+// alvo, o servidor encerra com erro em vez de abrir em um ponto anterior.
+//
+// Retorna um resumo da configuração para a saída da restauração.
+func (ppbs *PostgresPhysicalBackupService) ConfigureRecovery(dataDir string, datasourceId string, target entity.RecoveryTarget) (string, error) {
+	if err := target.Validate(); err != nil {
+		return "", err
+	}
+	walDir, err := filepath.Abs(ppbs.config.WalDirectory(datasourceId))
+	if err != nil {
+		return "", err
+	}
+
+	settings := []string{
+		fmt.Sprintf("restore_command = %s", quoteLiteral(fmt.Sprintf(`gzip -dc "%s/%%f.gz" > "%%p"`, walDir))),
+		"recovery_target_action = 'promote'",
+	}
+	description := ""
+	if target.Time != nil {
+		settings = append(settings, fmt.Sprintf("recovery_target_time = %s", quoteLiteral(target.Time.UTC().Format("2006-01-02 15:04:05.999999-07"))))
+		description = target.Time.UTC().Format(time.RFC3339Nano)
+	} else {
+		settings = append(settings, fmt.Sprintf("recovery_target_lsn = %s", quoteLiteral(target.LSN)))
+		description = "LSN " + target.LSN
+	}
+	if err := appendAutoConf(dataDir, settings...); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "recovery.signal"), nil, 0600); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("recuperação configurada até %s com os WAL de %s; o servidor é promovido ao alcançar o alvo", description, walDir), nil
+}
+
+// appendAutoConf acrescenta parâmetros ao postgresql.auto.conf, que prevalece sobre o postgresql.conf.
+func appendAutoConf(dataDir string, settings ...string) error {
+	f, err := os.OpenFile(filepath.Join(dataDir, "postgresql.auto.conf"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "\n# database-backup-management\n%s\n", strings.Join(settings, "\n"))
+	return err
+}
+
+// buildCommand monta o comando com as variáveis PGPASSWORD e PGSSLMODE, como em PostgresBackupService.
+func (*PostgresPhysicalBackupService) buildCommand(ds entity.Datasource, ctx context.Context, executable string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("PGPASSWORD=%s", ds.Password),
+		fmt.Sprintf("PGSSLMODE=%s", ds.SSLMode),
+	)
+	return cmd
+}
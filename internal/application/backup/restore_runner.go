@@ -0,0 +1,345 @@
+package backup
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	notificationEntity "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/google/uuid"
+)
+
+// NewDatabaseConfig define os padrões das restaurações no modo new_database.
+type NewDatabaseConfig struct {
+	// NameTemplate é o modelo do nome do banco, com os marcadores {db} e {timestamp}.
+	NameTemplate string
+	// TTL é o tempo até a remoção automática do banco quando a requisição não informa um.
+	TTL time.Duration
+}
+
+// NewDatabaseConfigFromEnv lê RESTORE_DATABASE_NAME_TEMPLATE e RESTORE_TEMPORARY_DATABASE_TTL (padrão 24h).
+func NewDatabaseConfigFromEnv() NewDatabaseConfig {
+	config := NewDatabaseConfig{
+		NameTemplate: os.Getenv("RESTORE_DATABASE_NAME_TEMPLATE"),
+		TTL:          24 * time.Hour,
+	}
+	if config.NameTemplate == "" {
+		config.NameTemplate = entity.DefaultDatabaseNameTemplate
+	}
+	if value := os.Getenv("RESTORE_TEMPORARY_DATABASE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Printf("RESTORE_TEMPORARY_DATABASE_TTL inválido (%q), usando %s", value, config.TTL)
+		} else {
+			config.TTL = ttl
+		}
+	}
+	return config
+}
+
+// TemporaryDatasourceTag identifica os datasources cadastrados para bancos temporários.
+const TemporaryDatasourceTag = "temporary"
+
+type RestoreRunner struct {
+	backupRepo            contract.IBackupRepository
+	restoreRepo           contract.IRestoreRepository
+	datasourceRepo        contract.IDatasourceRepository
+	temporaryDatabaseRepo contract.ITemporaryDatabaseRepository
+	walSegmentRepo        contract.IWalSegmentRepository
+	backupService         contract.IBackupService
+	physicalBackupService contract.IPhysicalBackupService
+	backupCommand         contract.ICommand
+	notifier              notificationContract.INotifier
+	newDatabaseConfig     NewDatabaseConfig
+}
+
+var _ contract.IRestoreRunner = (*RestoreRunner)(nil)
+
+func NewRestoreRunner(backupRepo contract.IBackupRepository, restoreRepo contract.IRestoreRepository, datasourceRepo contract.IDatasourceRepository, temporaryDatabaseRepo contract.ITemporaryDatabaseRepository, walSegmentRepo contract.IWalSegmentRepository, backupService contract.IBackupService, physicalBackupService contract.IPhysicalBackupService, backupCommand contract.ICommand, notifier notificationContract.INotifier, newDatabaseConfig NewDatabaseConfig) *RestoreRunner {
+	return &RestoreRunner{backupRepo, restoreRepo, datasourceRepo, temporaryDatabaseRepo, walSegmentRepo, backupService, physicalBackupService, backupCommand, notifier, newDatabaseConfig}
+}
+
+// Run implements IRestoreRunner.
+func (rr *RestoreRunner) Run(restore entity.Restore, backup entity.Backup, ds entity.Datasource) (entity.Restore, error) {
+	if err := rr.restoreRepo.CreateRestore(restore); err != nil {
+		return restore, fmt.Errorf("erro ao registrar a restauração: %w", err)
+	}
+
+	go rr.restore(restore, backup, ds)
+	return restore, nil
+}
+
+// CheckScope implements IRestoreRunner.
+func (rr *RestoreRunner) CheckScope(backup entity.Backup, scope entity.RestoreScope) error {
+	if err := scope.Validate(); err != nil {
+		return err
+	}
+	if !scope.IsSelective() {
+		return nil
+	}
+
+	toc, err := rr.backupService.ListContents(backup.FilePath)
+	if errors.Is(err, entity.ErrInvalidRestoreOptions) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao ler o conteúdo do backup: %w", err)
+	}
+
+	return checkScopeObjects(toc, scope)
+}
+
+// restore faz um snapshot de segurança do datasource de destino (trigger pre-restore) e, somente se ele
+// for concluído, restaura o backup. O snapshot permite desfazer a restauração via rollback.
+func (rr *RestoreRunner) restore(restore entity.Restore, backup entity.Backup, ds entity.Datasource) {
+	decodedDs, err := ds.Decode()
+	if err != nil {
+		rr.fail(restore, backup, ds, fmt.Errorf("erro ao decodificar datasource: %w", err), "")
+		return
+	}
+
+	snapshot, err := rr.backupCommand.Run(ds, entity.BackupPreRestore)
+	if snapshot.ID != "" {
+		snapshot.PreRestoreOf = &backup.ID
+		if err := rr.backupRepo.UpdateBackup(snapshot); err != nil {
+			log.Printf("erro ao vincular o snapshot %s ao backup %s: %v", snapshot.ID, backup.ID, err)
+		}
+		restore.PreRestoreBackupId = &snapshot.ID
+		rr.save(restore)
+	}
+	if err != nil {
+		rr.fail(restore, backup, ds, fmt.Errorf("restauração cancelada: falha no snapshot de segurança do destino: %w", err), "")
+		return
+	}
+
+	globalsOutput, scope, err := rr.restoreGlobals(decodedDs, backup, restore.Scope)
+	if err != nil {
+		rr.fail(restore, backup, ds, fmt.Errorf("%w (snapshot anterior à restauração: %s)", err, snapshot.ID), globalsOutput)
+		return
+	}
+	output, err := rr.backupService.Restore(decodedDs, backup.FilePath, backup.ClientVersion, scope)
+	output = globalsOutput + output
+	if err != nil {
+		rr.fail(restore, backup, ds, fmt.Errorf("%w (snapshot anterior à restauração: %s)", err, snapshot.ID), output)
+		return
+	}
+	rr.complete(restore, backup, ds, output)
+}
+
+// restoreGlobals restaura os objetos globais gravados junto do backup, antes do banco, para que os roles
+// donos dos objetos existam no servidor de destino. Retorna a saída do psql e o escopo da restauração do
+// banco, que mantém os donos dos objetos quando os globais foram restaurados.
+func (rr *RestoreRunner) restoreGlobals(ds entity.Datasource, backup entity.Backup, scope entity.RestoreScope) (string, entity.RestoreScope, error) {
+	if backup.GlobalsFilePath == "" || scope.SkipGlobals {
+		return "", scope, nil
+	}
+	output, err := rr.backupService.RestoreGlobals(ds, backup.GlobalsFilePath)
+	if err != nil {
+		return output, scope, err
+	}
+	scope.KeepOwners = true
+	return output + "\n", scope, nil
+}
+
+// RunNewDatabase implements IRestoreRunner.
+//
+// Sem nome ou ttl nas opções, são usados o modelo de nome e o ttl padrão de NewDatabaseConfig.
+func (rr *RestoreRunner) RunNewDatabase(restore entity.Restore, backup entity.Backup, ds entity.Datasource, options entity.RestoreOptions) (entity.Restore, entity.TemporaryDatabase, error) {
+	if options.TargetDatabase == "" {
+		options.TargetDatabase = entity.NewDatabaseName(rr.newDatabaseConfig.NameTemplate, ds.Database, time.Now())
+	}
+	if options.TTL == 0 {
+		options.TTL = rr.newDatabaseConfig.TTL
+	}
+	if err := options.Validate(); err != nil {
+		return restore, entity.TemporaryDatabase{}, err
+	}
+	decodedDs, err := ds.Decode()
+	if err != nil {
+		return restore, entity.TemporaryDatabase{}, fmt.Errorf("erro ao decodificar datasource: %w", err)
+	}
+
+	restore.Mode = entity.RestoreNewDatabase
+	restore.Database = options.TargetDatabase
+	if err := rr.restoreRepo.CreateRestore(restore); err != nil {
+		return restore, entity.TemporaryDatabase{}, fmt.Errorf("erro ao registrar a restauração: %w", err)
+	}
+
+	target := decodedDs
+	target.Database = options.TargetDatabase
+	if err := rr.backupService.CreateDatabase(target); err != nil {
+		restore.SetFailed(err, "")
+		rr.save(restore)
+		return restore, entity.TemporaryDatabase{}, err
+	}
+
+	database := entity.NewTemporaryDatabase(ds.ID, backup.ID, options.TargetDatabase, options.TTL)
+	if err := rr.temporaryDatabaseRepo.CreateTemporaryDatabase(*database); err != nil {
+		if dropErr := rr.backupService.DropDatabase(target); dropErr != nil {
+			log.Printf("erro ao remover o banco %s: %v", target.Database, dropErr)
+		}
+		err = fmt.Errorf("erro ao registrar o banco temporário: %w", err)
+		restore.SetFailed(err, "")
+		rr.save(restore)
+		return restore, entity.TemporaryDatabase{}, err
+	}
+	restore.TemporaryDatabaseId = &database.ID
+	rr.save(restore)
+
+	go rr.restoreNewDatabase(restore, backup, ds, target, *database, options)
+	return restore, *database, nil
+}
+
+// restoreNewDatabase restaura o backup no banco recém-criado. Não há snapshot de segurança, pois o banco
+// é novo; em caso de falha ele é removido imediatamente. Se solicitado, o banco é cadastrado como
+// datasource temporário (sem agendamento e com a tag "temporary").
+func (rr *RestoreRunner) restoreNewDatabase(restore entity.Restore, backup entity.Backup, ds, target entity.Datasource, database entity.TemporaryDatabase, options entity.RestoreOptions) {
+	targetDs := ds
+	targetDs.Database = database.Database
+
+	globalsOutput, scope, err := rr.restoreGlobals(target, backup, restore.Scope)
+	output := globalsOutput
+	if err == nil {
+		output, err = rr.backupService.Restore(target, backup.FilePath, backup.ClientVersion, scope)
+		output = globalsOutput + output
+	}
+	if err != nil {
+		if dropErr := rr.backupService.DropDatabase(target); dropErr != nil {
+			log.Printf("erro ao remover o banco %s: %v", database.Database, dropErr)
+			database.SetError(dropErr)
+		} else {
+			database.SetDropped()
+			database.SetError(err)
+		}
+		if err := rr.temporaryDatabaseRepo.UpdateTemporaryDatabase(database); err != nil {
+			log.Printf("erro ao atualizar o banco temporário %s: %v", database.ID, err)
+		}
+		rr.fail(restore, backup, targetDs, err, output)
+		return
+	}
+
+	if options.RegisterDatasource {
+		registered := ds
+		registered.ID = uuid.New().String()
+		registered.Database = database.Database
+		registered.Cron = &entity.CronExpr{Enabled: false}
+		registered.Protected = false
+		registered.SetTags(append(append([]string{}, ds.Tags...), TemporaryDatasourceTag))
+		if err := rr.datasourceRepo.CreateDatasource(registered); err != nil {
+			log.Printf("erro ao cadastrar o datasource temporário do banco %s: %v", database.Database, err)
+		} else {
+			database.RegisteredDatasourceId = &registered.ID
+			if err := rr.temporaryDatabaseRepo.UpdateTemporaryDatabase(database); err != nil {
+				log.Printf("erro ao atualizar o banco temporário %s: %v", database.ID, err)
+			}
+			targetDs = registered
+		}
+	}
+
+	rr.complete(restore, backup, targetDs, output)
+}
+
+// RunDataDirectory implements IRestoreRunner.
+//
+// Sem nome nas opções, o diretório é nomeado pelo modelo DefaultDataDirectoryNameTemplate.
+func (rr *RestoreRunner) RunDataDirectory(restore entity.Restore, backup entity.Backup, ds entity.Datasource, options entity.RestoreOptions) (entity.Restore, error) {
+	if options.DataDirectory == "" {
+		options.DataDirectory = entity.NewDatabaseName(entity.DefaultDataDirectoryNameTemplate, ds.Database, time.Now())
+	}
+	if err := options.Validate(); err != nil {
+		return restore, err
+	}
+	if options.RecoveryTarget != nil {
+		if err := rr.checkRecoveryTarget(backup, *options.RecoveryTarget); err != nil {
+			return restore, err
+		}
+	}
+
+	restore.Mode = entity.RestoreDataDirectory
+	restore.Database = options.DataDirectory
+	restore.RecoveryTarget = options.RecoveryTarget
+	if err := rr.restoreRepo.CreateRestore(restore); err != nil {
+		return restore, fmt.Errorf("erro ao registrar a restauração: %w", err)
+	}
+
+	go rr.restoreDataDirectory(restore, backup, ds, options.DataDirectory)
+	return restore, nil
+}
+
+// checkRecoveryTarget confere o alvo da recuperação contra o catálogo de WAL do datasource do backup: é
+// preciso haver WAL arquivado e, para um instante, ele deve estar entre o fim do backup e o último WAL
+// recebido. Um LSN não pode ser conferido sem ler os WAL e é validado apenas na recuperação.
+func (rr *RestoreRunner) checkRecoveryTarget(backup entity.Backup, target entity.RecoveryTarget) error {
+	latest, err := rr.walSegmentRepo.GetLatestWalSegment(backup.DatasourceId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: não há WAL arquivado para o datasource do backup", entity.ErrInvalidRestoreOptions)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao consultar o catálogo de WAL: %w", err)
+	}
+	if target.Time == nil {
+		return nil
+	}
+	if backup.FinishedAt != nil && target.Time.Before(*backup.FinishedAt) {
+		return fmt.Errorf("%w: o alvo é anterior ao fim do backup (%s)", entity.ErrInvalidRestoreOptions, backup.FinishedAt.Format(time.RFC3339))
+	}
+	if target.Time.After(latest.ArchivedAt) {
+		return fmt.Errorf("%w: o alvo é posterior ao último WAL arquivado (%s, em %s)", entity.ErrInvalidRestoreOptions, latest.FileName, latest.ArchivedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// restoreDataDirectory extrai o backup físico no diretório de dados e, com um alvo de recuperação, configura o
+// PITR. Nenhum servidor é alterado, por isso não há snapshot de segurança; ao concluir, restore.Database guarda
+// o caminho completo do diretório.
+func (rr *RestoreRunner) restoreDataDirectory(restore entity.Restore, backup entity.Backup, ds entity.Datasource, name string) {
+	dataDir, output, err := rr.physicalBackupService.RestoreDataDirectory(backup.FilePath, name)
+	if dataDir != "" {
+		restore.Database = dataDir
+	}
+	if err != nil {
+		rr.fail(restore, backup, ds, err, output)
+		return
+	}
+
+	if restore.RecoveryTarget != nil {
+		recovery, err := rr.physicalBackupService.ConfigureRecovery(dataDir, backup.DatasourceId, *restore.RecoveryTarget)
+		if err != nil {
+			rr.fail(restore, backup, ds, fmt.Errorf("erro ao configurar a recuperação: %w", err), output)
+			return
+		}
+		output += "\n" + recovery
+	}
+	rr.complete(restore, backup, ds, output)
+}
+
+// complete registra a conclusão da restauração, marca o backup como restaurado e notifica.
+func (rr *RestoreRunner) complete(restore entity.Restore, backup entity.Backup, ds entity.Datasource, output string) {
+	restore.SetCompleted(output)
+	rr.save(restore)
+
+	backup.SetRestoredAt()
+	rr.backupRepo.UpdateBackup(backup)
+	rr.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRestoreCompleted, ds, &backup))
+}
+
+// fail registra a falha da restauração, com a saída do comando, e notifica.
+func (rr *RestoreRunner) fail(restore entity.Restore, backup entity.Backup, ds entity.Datasource, err error, output string) {
+	log.Printf("erro ao restaurar o backup: %v", err)
+	restore.SetFailed(err, output)
+	rr.save(restore)
+
+	rr.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRestoreFailed, ds, &backup).WithError(err))
+}
+
+func (rr *RestoreRunner) save(restore entity.Restore) {
+	if err := rr.restoreRepo.UpdateRestore(restore); err != nil {
+		log.Printf("erro ao atualizar a restauração %s: %v", restore.ID, err)
+	}
+}
@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+// TemporaryDatabaseCleaner remove periodicamente os bancos temporários expirados.
+type TemporaryDatabaseCleaner struct {
+	temporaryDatabaseRepo contract.ITemporaryDatabaseRepository
+	datasourceRepo        contract.IDatasourceRepository
+	backupService         contract.IBackupService
+	interval              time.Duration
+	ctx                   context.Context
+	cancelCtx             context.CancelFunc
+}
+
+var _ contract.ITemporaryDatabaseCleaner = (*TemporaryDatabaseCleaner)(nil)
+
+func NewTemporaryDatabaseCleaner(temporaryDatabaseRepo contract.ITemporaryDatabaseRepository, datasourceRepo contract.IDatasourceRepository, backupService contract.IBackupService) *TemporaryDatabaseCleaner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TemporaryDatabaseCleaner{
+		temporaryDatabaseRepo: temporaryDatabaseRepo,
+		datasourceRepo:        datasourceRepo,
+		backupService:         backupService,
+		interval:              1 * time.Minute,
+		ctx:                   ctx,
+		cancelCtx:             cancel,
+	}
+}
+
+func (tc *TemporaryDatabaseCleaner) Start() {
+	go func() {
+		tc.DropExpired()
+
+		ticker := time.NewTicker(tc.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				tc.DropExpired()
+			case <-tc.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (tc *TemporaryDatabaseCleaner) Stop() {
+	tc.cancelCtx()
+}
+
+// DropExpired remove os bancos temporários cujo ttl expirou. Falhas são registradas no banco
+// temporário e a remoção é tentada novamente no próximo ciclo.
+func (tc *TemporaryDatabaseCleaner) DropExpired() {
+	databases, err := tc.temporaryDatabaseRepo.GetExpiredTemporaryDatabases(time.Now())
+	if err != nil {
+		log.Printf("Erro ao carregar bancos temporários expirados: %v", err)
+		return
+	}
+	for _, database := range databases {
+		if _, err := tc.Drop(database); err != nil {
+			log.Printf("[TEMPORARY DATABASE ERROR] Database: %s, Error: %s", database.Database, err)
+			continue
+		}
+		log.Printf("Banco temporário %s removido.", database.Database)
+	}
+}
+
+// Drop implements ITemporaryDatabaseCleaner.
+//
+// O banco é removido com as credenciais do datasource temporário, quando cadastrado, ou com as do
+// datasource de origem.
+func (tc *TemporaryDatabaseCleaner) Drop(database entity.TemporaryDatabase) (entity.TemporaryDatabase, error) {
+	if database.DroppedAt != nil {
+		return database, nil
+	}
+
+	err := tc.drop(database)
+	if err != nil {
+		database.SetError(err)
+	} else {
+		database.SetDropped()
+	}
+	if updateErr := tc.temporaryDatabaseRepo.UpdateTemporaryDatabase(database); updateErr != nil && err == nil {
+		err = fmt.Errorf("erro ao atualizar o banco temporário: %w", updateErr)
+	}
+	return database, err
+}
+
+func (tc *TemporaryDatabaseCleaner) drop(database entity.TemporaryDatabase) error {
+	var (
+		ds  entity.Datasource
+		err error
+	)
+	if database.RegisteredDatasourceId != nil {
+		ds, err = tc.datasourceRepo.GetDatasource(*database.RegisteredDatasourceId)
+	}
+	if database.RegisteredDatasourceId == nil || errors.Is(err, sql.ErrNoRows) {
+		ds, err = tc.datasourceRepo.GetDatasource(database.DatasourceId)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao carregar o datasource: %w", err)
+	}
+
+	decodedDs, err := ds.Decode()
+	if err != nil {
+		return fmt.Errorf("erro ao decodificar datasource: %w", err)
+	}
+	decodedDs.Database = database.Database
+	if err := tc.backupService.DropDatabase(decodedDs); err != nil {
+		return err
+	}
+
+	if database.RegisteredDatasourceId != nil {
+		err := tc.datasourceRepo.DeleteDatasource(*database.RegisteredDatasourceId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("erro ao remover o datasource temporário: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type WalArchive struct {
+	walSegmentRepo contract.IWalSegmentRepository
+	config         PhysicalBackupConfig
+}
+
+var _ contract.IWalArchive = (*WalArchive)(nil)
+
+func NewWalArchive(walSegmentRepo contract.IWalSegmentRepository, config PhysicalBackupConfig) *WalArchive {
+	return &WalArchive{walSegmentRepo, config}
+}
+
+// Archive implements IWalArchive.
+//
+// O arquivo é gravado em <WalArchiveRoot>/<datasource>/<nome>.gz, primeiro em um arquivo temporário no
+// mesmo diretório e só então renomeado, para que uma recuperação nunca leia um segmento incompleto. O
+// PostgreSQL repete o archive_command até receber sucesso, por isso reenvios idênticos são aceitos.
+func (wa *WalArchive) Archive(ds entity.Datasource, fileName string, r io.Reader) (entity.WalSegment, bool, error) {
+	if !entity.ValidWalFileName(fileName) {
+		return entity.WalSegment{}, false, fmt.Errorf("%w: %q", entity.ErrInvalidWalFileName, fileName)
+	}
+
+	dir := wa.config.WalDirectory(ds.ID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao criar o diretório de WAL: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".incoming-*")
+	if err != nil {
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao criar o arquivo de WAL: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	gz := gzip.NewWriter(tmp)
+	_, err = io.Copy(io.MultiWriter(gz, hash), r)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao gravar o arquivo de WAL: %w", err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	existing, err := wa.walSegmentRepo.GetWalSegment(ds.ID, fileName)
+	if err == nil {
+		if existing.Checksum != checksum {
+			return entity.WalSegment{}, false, fmt.Errorf("%w: %s", entity.ErrWalSegmentConflict, fileName)
+		}
+		return existing, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao consultar o catálogo de WAL: %w", err)
+	}
+
+	info, err := os.Stat(tmp.Name())
+	if err != nil {
+		return entity.WalSegment{}, false, err
+	}
+	segment := entity.NewWalSegment(ds.ID, fileName)
+	segment.FilePath = filepath.Join(dir, fileName+".gz")
+	segment.FileSize = info.Size()
+	segment.Checksum = checksum
+	if err := os.Rename(tmp.Name(), segment.FilePath); err != nil {
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao gravar o arquivo de WAL: %w", err)
+	}
+	if err := wa.walSegmentRepo.CreateWalSegment(*segment); err != nil {
+		os.Remove(segment.FilePath)
+		return entity.WalSegment{}, false, fmt.Errorf("erro ao registrar o arquivo de WAL: %w", err)
+	}
+	return *segment, true, nil
+}
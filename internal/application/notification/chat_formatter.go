@@ -0,0 +1,108 @@
+package notification
+
+import "encoding/json"
+
+const (
+	colorSuccess = "#2eb886"
+	colorFailure = "#d40e0d"
+	colorInfo    = "#439fe0"
+)
+
+type chatFact struct {
+	Title string
+	Value string
+}
+
+// chatMessage é a representação neutra de uma mensagem, convertida para o formato de cada plataforma.
+type chatMessage struct {
+	Title string
+	Color string
+	Facts []chatFact
+	Link  string
+}
+
+// formatSlack gera o payload de um webhook de entrada compatível com o Slack
+// (também aceito por Mattermost e Rocket.Chat), usando attachments com campos.
+func formatSlack(message chatMessage) ([]byte, error) {
+	type slackField struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+		Short bool   `json:"short"`
+	}
+	type slackAttachment struct {
+		Fallback  string       `json:"fallback"`
+		Color     string       `json:"color"`
+		Title     string       `json:"title"`
+		TitleLink string       `json:"title_link,omitempty"`
+		Fields    []slackField `json:"fields"`
+	}
+
+	fields := make([]slackField, 0, len(message.Facts))
+	for _, fact := range message.Facts {
+		fields = append(fields, slackField{fact.Title, fact.Value, fact.Title != "Erro"})
+	}
+
+	return json.Marshal(map[string]any{
+		"text": message.Title,
+		"attachments": []slackAttachment{{
+			Fallback:  message.Title,
+			Color:     message.Color,
+			Title:     message.Title,
+			TitleLink: message.Link,
+			Fields:    fields,
+		}},
+	})
+}
+
+// formatTeams gera o payload de um webhook de entrada do Microsoft Teams com um Adaptive Card.
+func formatTeams(message chatMessage) ([]byte, error) {
+	style := "good"
+	switch message.Color {
+	case colorFailure:
+		style = "attention"
+	case colorInfo:
+		style = "accent"
+	}
+
+	facts := make([]map[string]string, 0, len(message.Facts))
+	for _, fact := range message.Facts {
+		facts = append(facts, map[string]string{"title": fact.Title, "value": fact.Value})
+	}
+
+	body := []map[string]any{
+		{
+			"type":   "TextBlock",
+			"text":   message.Title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"color":  style,
+			"wrap":   true,
+		},
+		{
+			"type":  "FactSet",
+			"facts": facts,
+		},
+	}
+
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.Link != "" {
+		card["actions"] = []map[string]string{{
+			"type":  "Action.OpenUrl",
+			"title": "Abrir no painel",
+			"url":   message.Link,
+		}}
+	}
+
+	return json.Marshal(map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	})
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	backupContract "github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	backupEntity "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+var (
+	chatMaxAttempts = 3
+	chatRetryDelay  = 2 * time.Second
+)
+
+type ChatNotifier struct {
+	channelRepo contract.IChatChannelRepository
+	backupRepo  backupContract.IBackupRepository
+	frontendURL string
+	client      *http.Client
+	pending     sync.WaitGroup
+}
+
+var _ contract.INotifier = (*ChatNotifier)(nil)
+var _ contract.IChatTester = (*ChatNotifier)(nil)
+
+func NewChatNotifier(channelRepo contract.IChatChannelRepository, backupRepo backupContract.IBackupRepository, frontendURL string) *ChatNotifier {
+	return &ChatNotifier{
+		channelRepo: channelRepo,
+		backupRepo:  backupRepo,
+		frontendURL: strings.TrimSuffix(frontendURL, "/"),
+		client:      &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Notify envia o evento para os canais de chat do datasource conforme a severidade configurada.
+func (cn *ChatNotifier) Notify(event entity.Event) {
+	cn.pending.Add(1)
+	go func() {
+		defer cn.pending.Done()
+		channels, err := cn.channelRepo.GetChannels(&event.DatasourceId)
+		if err != nil {
+			log.Printf("[CHAT ERROR] Evento: %s, Error: %s", event.Type, err.Error())
+			return
+		}
+
+		var message *chatMessage
+		for _, channel := range channels {
+			if !channel.Accepts(event) {
+				continue
+			}
+			if message == nil {
+				built := cn.buildMessage(event)
+				message = &built
+			}
+			if err := cn.send(channel, *message); err != nil {
+				log.Printf("[CHAT ERROR] Canal: %s, Evento: %s, Error: %s", channel.ID, event.Type, err.Error())
+			}
+		}
+	}()
+}
+
+// Wait aguarda os envios em segundo plano em andamento.
+func (cn *ChatNotifier) Wait() {
+	cn.pending.Wait()
+}
+
+// SendTest envia uma mensagem de teste ao canal informado.
+func (cn *ChatNotifier) SendTest(channel entity.ChatChannel) error {
+	return cn.send(channel, chatMessage{
+		Title: "Mensagem de teste do Database Backup Management",
+		Color: colorInfo,
+		Link:  cn.frontendURL,
+	})
+}
+
+// send formata a mensagem conforme o tipo do canal e a envia, com novas tentativas em caso de falha.
+func (cn *ChatNotifier) send(channel entity.ChatChannel, message chatMessage) error {
+	decodedChannel, err := channel.Decode()
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	switch decodedChannel.Kind {
+	case entity.ChatSlack:
+		payload, err = formatSlack(message)
+	case entity.ChatTeams:
+		payload, err = formatTeams(message)
+	default:
+		err = fmt.Errorf("tipo de canal inválido: %s", decodedChannel.Kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		status, err := cn.post(decodedChannel.WebhookURL, payload)
+		if err == nil {
+			return nil
+		}
+		if !isRetryable(status) || attempt == chatMaxAttempts {
+			return err
+		}
+		time.Sleep(chatRetryDelay * time.Duration(attempt))
+	}
+}
+
+func (cn *ChatNotifier) post(url string, payload []byte) (int, error) {
+	resp, err := cn.client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook de chat respondeu com status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (cn *ChatNotifier) buildMessage(event entity.Event) chatMessage {
+	message := chatMessage{
+		Title: eventTitle(event),
+		Color: colorSuccess,
+	}
+	if event.IsFailure() {
+		message.Color = colorFailure
+	} else if event.Type == entity.EventBackupStarted || event.Type == entity.EventBackupSkipped || event.Type == entity.EventRetentionDeleted || event.Type == entity.EventRestoreRequested {
+		message.Color = colorInfo
+	}
+
+	message.Facts = append(message.Facts, chatFact{"Datasource", fmt.Sprintf("%s @ %s", event.Database, event.Host)})
+	if backup := event.Backup; backup != nil {
+		message.Facts = append(message.Facts, chatFact{"Status", string(backup.Status)})
+		if backup.FileSize > 0 {
+			size := utils.FormatBytes(backup.FileSize)
+			if backup.StartedAt != nil {
+				previous, err := cn.backupRepo.GetPreviousCompletedBackup(backup.DatasourceId, *backup.StartedAt)
+				if err == nil {
+					size = fmt.Sprintf("%s (%s vs anterior)", size, formatSizeDelta(backup.FileSize-previous.FileSize))
+				}
+			}
+			message.Facts = append(message.Facts, chatFact{"Tamanho", size})
+		}
+		if duration := backup.Duration(); duration > 0 {
+			message.Facts = append(message.Facts, chatFact{"Duração", duration.Round(time.Second).String()})
+		}
+	}
+	if request := event.RestoreRequest; request != nil {
+		message.Facts = append(message.Facts, chatFact{"Solicitado por", request.RequestedByName})
+		if request.Reason != "" {
+			message.Facts = append(message.Facts, chatFact{"Motivo", request.Reason})
+		}
+		if request.DecidedByName != nil {
+			message.Facts = append(message.Facts, chatFact{"Decidido por", *request.DecidedByName})
+		}
+		if request.ExpiresAt != nil && request.Status == backupEntity.RestoreRequestPending {
+			message.Facts = append(message.Facts, chatFact{"Expira em", request.ExpiresAt.Format(time.RFC3339)})
+		}
+	}
+	if event.Error != "" {
+		message.Facts = append(message.Facts, chatFact{"Erro", event.Error})
+	}
+	if cn.frontendURL != "" && event.DatasourceId != "" {
+		message.Link = fmt.Sprintf("%s/datasources/%s", cn.frontendURL, event.DatasourceId)
+	}
+	return message
+}
+
+func eventTitle(event entity.Event) string {
+	switch event.Type {
+	case entity.EventBackupStarted:
+		return fmt.Sprintf("Backup iniciado: %s", event.Database)
+	case entity.EventBackupCompleted:
+		return fmt.Sprintf("Backup concluído: %s", event.Database)
+	case entity.EventBackupFailed:
+		return fmt.Sprintf("Falha no backup: %s", event.Database)
+	case entity.EventBackupSkipped:
+		return fmt.Sprintf("Backup ignorado pela janela de bloqueio: %s", event.Database)
+	case entity.EventRestoreCompleted:
+		return fmt.Sprintf("Restauração concluída: %s", event.Database)
+	case entity.EventRestoreFailed:
+		return fmt.Sprintf("Falha na restauração: %s", event.Database)
+	case entity.EventRestoreRequested:
+		return fmt.Sprintf("Restauração aguardando aprovação: %s", event.Database)
+	case entity.EventRestoreApproved:
+		return fmt.Sprintf("Restauração aprovada: %s", event.Database)
+	case entity.EventRestoreRejected:
+		return fmt.Sprintf("Restauração rejeitada: %s", event.Database)
+	case entity.EventRetentionDeleted:
+		return fmt.Sprintf("Backup removido pela retenção: %s", event.Database)
+	default:
+		return fmt.Sprintf("%s: %s", event.Type, event.Database)
+	}
+}
+
+func formatSizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + utils.FormatBytes(-delta)
+	}
+	return "+" + utils.FormatBytes(delta)
+}
@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+)
+
+// Dispatcher repassa cada evento para todos os notificadores configurados.
+type Dispatcher struct {
+	notifiers []contract.INotifier
+}
+
+var _ contract.INotifier = (*Dispatcher)(nil)
+
+func NewDispatcher(notifiers ...contract.INotifier) *Dispatcher {
+	return &Dispatcher{notifiers}
+}
+
+func (d *Dispatcher) Notify(event entity.Event) {
+	for _, notifier := range d.notifiers {
+		notifier.Notify(event)
+	}
+}
+
+// Wait aguarda as entregas em segundo plano dos notificadores que permitem aguardá-las. Usado por processos
+// que encerram logo após publicar os eventos, como a CLI.
+func (d *Dispatcher) Wait() {
+	for _, notifier := range d.notifiers {
+		if waiter, ok := notifier.(interface{ Wait() }); ok {
+			waiter.Wait()
+		}
+	}
+}
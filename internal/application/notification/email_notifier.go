@@ -0,0 +1,230 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	backupContract "github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	backupEntity "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/robfig/cron/v3"
+)
+
+type EmailNotifier struct {
+	mailer         contract.IMailer
+	recipientRepo  contract.IEmailRecipientRepository
+	datasourceRepo backupContract.IDatasourceRepository
+	backupRepo     backupContract.IBackupRepository
+	cron           *cron.Cron
+	pending        sync.WaitGroup
+}
+
+var _ contract.INotifier = (*EmailNotifier)(nil)
+var _ contract.IDigestSender = (*EmailNotifier)(nil)
+
+func NewEmailNotifier(mailer contract.IMailer, recipientRepo contract.IEmailRecipientRepository, datasourceRepo backupContract.IDatasourceRepository, backupRepo backupContract.IBackupRepository) *EmailNotifier {
+	return &EmailNotifier{
+		mailer:         mailer,
+		recipientRepo:  recipientRepo,
+		datasourceRepo: datasourceRepo,
+		backupRepo:     backupRepo,
+		cron:           cron.New(cron.WithSeconds()),
+	}
+}
+
+// StartDigest agenda o envio do resumo diário conforme a expressão cron informada (com segundos).
+func (en *EmailNotifier) StartDigest(cronExpr string) error {
+	_, err := en.cron.AddFunc(cronExpr, func() {
+		if err := en.SendDigest(); err != nil {
+			log.Printf("[EMAIL DIGEST ERROR] Error: %s", err.Error())
+		}
+	})
+	if err != nil {
+		return err
+	}
+	en.cron.Start()
+	log.Printf("Resumo diário por e-mail agendado: %s", cronExpr)
+	return nil
+}
+
+func (en *EmailNotifier) Stop() {
+	en.cron.Stop()
+}
+
+// Notify envia alertas imediatos por e-mail para os eventos que exigem ação: falhas e restaurações aguardando
+// aprovação (ver Event.NeedsAttention).
+func (en *EmailNotifier) Notify(event entity.Event) {
+	if !event.NeedsAttention() {
+		return
+	}
+
+	en.pending.Add(1)
+	go func() {
+		defer en.pending.Done()
+		recipients, err := en.recipientRepo.GetRecipients(&event.DatasourceId)
+		if err != nil {
+			log.Printf("[EMAIL ALERT ERROR] Evento: %s, Error: %s", event.Type, err.Error())
+			return
+		}
+
+		to := make([]string, 0, len(recipients))
+		for _, recipient := range recipients {
+			if recipient.Alerts {
+				to = append(to, recipient.Email)
+			}
+		}
+		to = uniqueEmails(to)
+		if len(to) == 0 {
+			return
+		}
+
+		subject, body := alertMessage(event)
+		if err := en.mailer.Send(to, subject, body); err != nil {
+			log.Printf("[EMAIL ALERT ERROR] Evento: %s, Error: %s", event.Type, err.Error())
+		}
+	}()
+}
+
+// Wait aguarda os alertas em segundo plano em andamento.
+func (en *EmailNotifier) Wait() {
+	en.pending.Wait()
+}
+
+// SendDigest envia o resumo com o último backup de cada datasource.
+//
+// Destinatários globais recebem o resumo de todos os datasources; os demais recebem
+// apenas os datasources aos quais estão vinculados.
+func (en *EmailNotifier) SendDigest() error {
+	recipients, err := en.recipientRepo.GetRecipients(nil)
+	if err != nil {
+		return err
+	}
+	datasources, err := en.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		return err
+	}
+	latestBackups, err := en.backupRepo.GetLatestBackups()
+	if err != nil {
+		return err
+	}
+
+	backupsByDatasource := make(map[string]backupEntity.Backup, len(latestBackups))
+	for _, backup := range latestBackups {
+		backupsByDatasource[backup.DatasourceId] = backup
+	}
+
+	datasourcesByEmail := make(map[string][]backupEntity.Datasource)
+	for _, recipient := range recipients {
+		if !recipient.Digest {
+			continue
+		}
+		for _, ds := range datasources {
+			if recipient.Covers(ds.ID) && !containsDatasource(datasourcesByEmail[recipient.Email], ds.ID) {
+				datasourcesByEmail[recipient.Email] = append(datasourcesByEmail[recipient.Email], ds)
+			}
+		}
+	}
+
+	subject := fmt.Sprintf("[DBBM] Resumo diário de backups - %s", time.Now().Format("02/01/2006"))
+	for email, emailDatasources := range datasourcesByEmail {
+		body := digestMessage(emailDatasources, backupsByDatasource)
+		if err := en.mailer.Send([]string{email}, subject, body); err != nil {
+			log.Printf("[EMAIL DIGEST ERROR] Destinatário: %s, Error: %s", email, err.Error())
+		}
+	}
+	return nil
+}
+
+func alertMessage(event entity.Event) (string, string) {
+	subject := fmt.Sprintf("[DBBM] Falha no backup do banco %s", event.Database)
+	if event.Type == entity.EventRestoreFailed {
+		subject = fmt.Sprintf("[DBBM] Falha na restauração do banco %s", event.Database)
+	}
+	if event.Type == entity.EventRestoreRequested {
+		subject = fmt.Sprintf("[DBBM] Restauração aguardando aprovação no banco %s", event.Database)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "Evento: %s\n", event.Type)
+	fmt.Fprintf(&body, "Datasource: %s (%s)\n", event.Database, event.DatasourceId)
+	fmt.Fprintf(&body, "Host: %s\n", event.Host)
+	if event.Backup != nil {
+		fmt.Fprintf(&body, "Backup: %s\n", event.Backup.ID)
+	}
+	if request := event.RestoreRequest; request != nil {
+		fmt.Fprintf(&body, "Solicitação: %s\n", request.ID)
+		fmt.Fprintf(&body, "Solicitado por: %s\n", request.RequestedByName)
+		if request.Reason != "" {
+			fmt.Fprintf(&body, "Motivo: %s\n", request.Reason)
+		}
+		if request.ExpiresAt != nil {
+			fmt.Fprintf(&body, "Expira em: %s\n", request.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+	fmt.Fprintf(&body, "Data: %s\n", event.OccurredAt.Format(time.RFC3339))
+	if event.Error != "" {
+		fmt.Fprintf(&body, "\nErro:\n%s\n", event.Error)
+	}
+	return subject, body.String()
+}
+
+func digestMessage(datasources []backupEntity.Datasource, backupsByDatasource map[string]backupEntity.Backup) string {
+	sort.Slice(datasources, func(i, j int) bool {
+		return datasources[i].Database < datasources[j].Database
+	})
+
+	var body bytes.Buffer
+	body.WriteString("Último backup de cada datasource:\n\n")
+
+	tw := tabwriter.NewWriter(&body, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATASOURCE\tHOST\tSTATUS\tINÍCIO\tTAMANHO\tDURAÇÃO")
+	for _, ds := range datasources {
+		backup, ok := backupsByDatasource[ds.ID]
+		if !ok {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t-\t-\t-\n", ds.Database, ds.Host, "sem backups")
+			continue
+		}
+		startedAt := "-"
+		if backup.StartedAt != nil {
+			startedAt = backup.StartedAt.Format("02/01/2006 15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			ds.Database,
+			ds.Host,
+			backup.Status,
+			startedAt,
+			utils.FormatBytes(backup.FileSize),
+			backup.Duration().Round(time.Second),
+		)
+	}
+	tw.Flush()
+	return body.String()
+}
+
+func containsDatasource(datasources []backupEntity.Datasource, datasourceId string) bool {
+	for _, ds := range datasources {
+		if ds.ID == datasourceId {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueEmails(emails []string) []string {
+	seen := make(map[string]bool, len(emails))
+	unique := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if !seen[email] {
+			seen[email] = true
+			unique = append(unique, email)
+		}
+	}
+	return unique
+}
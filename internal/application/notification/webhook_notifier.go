@@ -0,0 +1,176 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+)
+
+const (
+	SignatureHeader = "X-DBBM-Signature"
+	TimestampHeader = "X-DBBM-Timestamp"
+	EventHeader     = "X-DBBM-Event"
+	DeliveryHeader  = "X-DBBM-Delivery"
+)
+
+var (
+	webhookMaxAttempts = 5
+	webhookBaseDelay   = 2 * time.Second
+	webhookTimeout     = 10 * time.Second
+)
+
+type WebhookNotifier struct {
+	webhookRepo  contract.IWebhookRepository
+	deliveryRepo contract.IWebhookDeliveryRepository
+	client       *http.Client
+	pending      sync.WaitGroup
+}
+
+var _ contract.INotifier = (*WebhookNotifier)(nil)
+var _ contract.IWebhookSender = (*WebhookNotifier)(nil)
+
+func NewWebhookNotifier(webhookRepo contract.IWebhookRepository, deliveryRepo contract.IWebhookDeliveryRepository) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client:       &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Notify envia o evento para todos os webhooks habilitados que o assinam.
+// A consulta dos webhooks e cada entrega são feitas em segundo plano, com novas tentativas em caso de falha.
+func (wn *WebhookNotifier) Notify(event entity.Event) {
+	wn.pending.Add(1)
+	go func() {
+		defer wn.pending.Done()
+		webhooks, err := wn.webhookRepo.GetWebhooks(&event.DatasourceId)
+		if err != nil {
+			log.Printf("[WEBHOOK ERROR] Evento: %s, Error: %s", event.Type, err.Error())
+			return
+		}
+
+		for _, webhook := range webhooks {
+			if !webhook.Subscribes(event) {
+				continue
+			}
+			wn.pending.Add(1)
+			go func(webhook entity.Webhook) {
+				defer wn.pending.Done()
+				if _, err := wn.Deliver(webhook, event, webhookMaxAttempts); err != nil {
+					log.Printf("[WEBHOOK ERROR] Webhook: %s, Evento: %s, Error: %s", webhook.ID, event.Type, err.Error())
+				}
+			}(webhook)
+		}
+	}()
+}
+
+// Wait aguarda as entregas em segundo plano em andamento, inclusive as novas tentativas.
+func (wn *WebhookNotifier) Wait() {
+	wn.pending.Wait()
+}
+
+// Deliver entrega um evento para o webhook informado, registrando a entrega e cada tentativa.
+//
+// O corpo é um JSON do evento assinado com HMAC-SHA256 usando o segredo do webhook.
+// A assinatura é enviada no cabeçalho X-DBBM-Signature no formato "sha256=<hex>",
+// calculada sobre "<timestamp>.<corpo>", com o timestamp enviado em X-DBBM-Timestamp.
+//
+// Em caso de erro de rede, status 429 ou 5xx, a entrega é repetida com backoff exponencial
+// até o limite de tentativas informado.
+//
+// Retorna:
+// - O registro da entrega com o status final.
+// - Um erro, caso todas as tentativas falhem.
+func (wn *WebhookNotifier) Deliver(webhook entity.Webhook, event entity.Event, maxAttempts int) (entity.WebhookDelivery, error) {
+	decodedWebhook, err := webhook.Decode()
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	delivery := entity.NewWebhookDelivery(webhook.ID, event, string(payload))
+	if err := wn.deliveryRepo.CreateDelivery(*delivery); err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		status, err := wn.send(decodedWebhook, event, delivery.ID, payload)
+		delivery.RegisterAttempt(status, err)
+		lastErr = err
+
+		if err == nil {
+			delivery.SetSucceeded()
+			break
+		}
+		if !isRetryable(status) || attempt == maxAttempts {
+			delivery.SetFailed()
+			break
+		}
+		if err := wn.deliveryRepo.UpdateDelivery(*delivery); err != nil {
+			log.Printf("[WEBHOOK DELIVERY ERROR] Entrega: %s, Error: %s", delivery.ID, err.Error())
+		}
+		time.Sleep(webhookBaseDelay * time.Duration(1<<(attempt-1)))
+	}
+
+	if err := wn.deliveryRepo.UpdateDelivery(*delivery); err != nil {
+		return *delivery, err
+	}
+	return *delivery, lastErr
+}
+
+func (wn *WebhookNotifier) send(webhook entity.Webhook, event entity.Event, deliveryId string, payload []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "database-backup-management")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, deliveryId)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, timestamp, payload))
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook respondeu com status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign calcula a assinatura HMAC-SHA256 (hex) de "<timestamp>.<payload>" com o segredo informado.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func isRetryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
@@ -0,0 +1,12 @@
+package contract
+
+import (
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+)
+
+// IAuditRepository persiste a trilha de auditoria. Os registros são somente inserção.
+type IAuditRepository interface {
+	CreateEntry(entity entity.Entry) error
+	GetEntries(filter dto.AuditFilter) ([]entity.Entry, error)
+}
@@ -0,0 +1,15 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+)
+
+// IRecorder registra uma operação na trilha de auditoria, obtendo o ator, o request ID
+// e o IP do cliente a partir do contexto da requisição.
+//
+// before e after podem ser nil (criação e remoção, respectivamente).
+type IRecorder interface {
+	Record(ctx context.Context, action entity.Action, resourceType, resourceId string, before, after any)
+}
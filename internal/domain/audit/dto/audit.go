@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// AuditFilter restringe a consulta da trilha de auditoria. Campos vazios não filtram.
+type AuditFilter struct {
+	ActorId      string
+	Action       string
+	ResourceType string
+	ResourceId   string
+	RequestId    string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Offset       int
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+var (
+	ActionDatasourceCreate     Action = "datasource.create"
+	ActionDatasourceUpdate     Action = "datasource.update"
+	ActionDatasourceDelete     Action = "datasource.delete"
+	ActionBackupCreate         Action = "backup.create"
+	ActionBackupRestore        Action = "backup.restore"
+	ActionBackupDelete         Action = "backup.delete"
+	ActionBackupRollback       Action = "backup.rollback"
+	ActionBackupExport         Action = "backup.export_table"
+	ActionBackupDownload       Action = "backup.download"
+	ActionBackupImport         Action = "backup.import"
+	ActionRestoreRequest       Action = "restore_request.create"
+	ActionRestoreApprove       Action = "restore_request.approve"
+	ActionRestoreReject        Action = "restore_request.reject"
+	ActionTemporaryDbDrop      Action = "temporary_database.drop"
+	ActionApiKeyCreate         Action = "api_key.create"
+	ActionApiKeyRevoke         Action = "api_key.revoke"
+	ActionUserCreate           Action = "user.create"
+	ActionUserUpdate           Action = "user.update"
+	ActionUserDelete           Action = "user.delete"
+	ActionGrantCreate          Action = "grant.create"
+	ActionGrantDelete          Action = "grant.delete"
+	ActionRoleCreate           Action = "role.create"
+	ActionRoleUpdate           Action = "role.update"
+	ActionRoleDelete           Action = "role.delete"
+	ActionBackupProfileCreate  Action = "backup_profile.create"
+	ActionBackupProfileUpdate  Action = "backup_profile.update"
+	ActionBackupProfileDelete  Action = "backup_profile.delete"
+	ActionScheduleCreate       Action = "schedule.create"
+	ActionScheduleUpdate       Action = "schedule.update"
+	ActionScheduleDelete       Action = "schedule.delete"
+	ActionBlackoutWindowCreate Action = "blackout_window.create"
+	ActionBlackoutWindowUpdate Action = "blackout_window.update"
+	ActionBlackoutWindowDelete Action = "blackout_window.delete"
+)
+
+// Actor identifica quem executou a operação auditada.
+type Actor struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Kind   string  `json:"kind"`
+	UserId *string `json:"user_id"`
+}
+
+// Entry é um registro imutável da trilha de auditoria.
+//
+// Before e After guardam o estado do recurso (com segredos mascarados) antes e depois da operação;
+// Changes lista os campos de primeiro nível que foram alterados.
+type Entry struct {
+	ID           string          `json:"id"`
+	Action       Action          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceId   string          `json:"resource_id"`
+	Actor        Actor           `json:"actor"`
+	RequestId    string          `json:"request_id"`
+	ClientIP     string          `json:"client_ip"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	Changes      []string        `json:"changes"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func NewEntry(action Action, resourceType, resourceId string, actor Actor) *Entry {
+	return &Entry{
+		ID:           uuid.New().String(),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Actor:        actor,
+		Changes:      make([]string, 0),
+		CreatedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+)
+
+type IApiKeyRepository interface {
+	GetApiKeys() ([]entity.ApiKey, error)
+	GetApiKey(entityID string) (entity.ApiKey, error)
+	GetApiKeyByHash(keyHash string) (entity.ApiKey, error)
+	CreateApiKey(entity entity.ApiKey) error
+	RevokeApiKey(entityID string, revokedAt time.Time) error
+	TouchApiKey(entityID string, usedAt time.Time) error
+}
@@ -0,0 +1,8 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+
+// IAuthenticator valida uma credencial apresentada na requisição e retorna o principal correspondente.
+type IAuthenticator interface {
+	Authenticate(credential string) (entity.Principal, error)
+}
@@ -0,0 +1,12 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+
+type IRoleRepository interface {
+	GetRoles() ([]entity.Role, error)
+	GetRole(entityID string) (entity.Role, error)
+	GetRoleByName(name string) (entity.Role, error)
+	CreateRole(entity entity.Role) error
+	UpdateRole(entity entity.Role) error
+	DeleteRole(entityID string) error
+}
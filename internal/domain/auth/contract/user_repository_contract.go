@@ -0,0 +1,15 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+
+type IUserRepository interface {
+	GetUsers() ([]entity.User, error)
+	GetUser(entityID string) (entity.User, error)
+	GetUserByEmail(email string) (entity.User, error)
+	CreateUser(entity entity.User) error
+	UpdateUser(entity entity.User) error
+	DeleteUser(entityID string) error
+	GetGrants(userId string) ([]entity.Grant, error)
+	CreateGrant(entity entity.Grant) error
+	DeleteGrant(userId, grantId string) error
+}
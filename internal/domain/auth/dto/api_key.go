@@ -0,0 +1,7 @@
+package dto
+
+type CreateApiKeyDto struct {
+	Name   string   `json:"name"`
+	UserId *string  `json:"user_id"`
+	Scopes []string `json:"scopes"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type CreateRoleDto struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleDto struct {
+	CreateRoleDto
+}
@@ -0,0 +1,17 @@
+package dto
+
+type CreateUserDto struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdateUserDto struct {
+	CreateUserDto
+}
+
+type CreateGrantDto struct {
+	RoleId       string  `json:"role_id"`
+	DatasourceId *string `json:"datasource_id"`
+	Tag          *string `json:"tag"`
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const apiKeyPrefix = "dbbm"
+
+type ApiKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	UserId     *string    `json:"user_id"`
+	KeyHash    string     `json:"-"`
+	Scopes     []Scope    `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// NewApiKey gera uma nova chave de API com os escopos informados.
+//
+// Quando userId é informado, a chave age em nome do usuário: os escopos limitam
+// as concessões do usuário. Sem usuário, os escopos valem para todos os datasources.
+//
+// A chave tem o formato "dbbm_<prefixo>_<segredo>" e apenas o seu hash SHA-256 é armazenado.
+//
+// Retorna:
+// - A entidade da chave (sem o segredo).
+// - A chave em texto puro, que deve ser exibida uma única vez ao usuário.
+// - Um erro, caso o nome ou os escopos sejam inválidos.
+func NewApiKey(name string, userId *string, scopes []Scope) (*ApiKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", fmt.Errorf("o nome da chave é obrigatório")
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("a chave deve possuir ao menos um escopo")
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("escopo inválido: %s", scope)
+		}
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, "", err
+	}
+	plainKey := fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, secret)
+
+	return &ApiKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    prefix,
+		UserId:    userId,
+		KeyHash:   HashApiKey(plainKey),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}, plainKey, nil
+}
+
+// HashApiKey retorna o hash SHA-256 (hex) da chave em texto puro.
+func HashApiKey(plainKey string) string {
+	sum := sha256.Sum256([]byte(plainKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *ApiKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *ApiKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+}
+
+func randomHex(length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Grant atribui uma role a um usuário, globalmente ou restrita a um datasource ou a uma tag de datasource.
+type Grant struct {
+	ID           string       `json:"id"`
+	UserId       string       `json:"user_id"`
+	RoleId       string       `json:"role_id"`
+	RoleName     string       `json:"role_name"`
+	Permissions  []Permission `json:"permissions"`
+	DatasourceId *string      `json:"datasource_id"`
+	Tag          *string      `json:"tag"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+func NewGrant(userId, roleId string, datasourceId, tag *string) (*Grant, error) {
+	if datasourceId != nil && tag != nil {
+		return nil, fmt.Errorf("a concessão deve ser restrita a um datasource ou a uma tag, não ambos")
+	}
+	return &Grant{
+		ID:           uuid.New().String(),
+		UserId:       userId,
+		RoleId:       roleId,
+		DatasourceId: datasourceId,
+		Tag:          tag,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func (g *Grant) IsGlobal() bool {
+	return g.DatasourceId == nil && g.Tag == nil
+}
+
+// Covers indica se a concessão se aplica ao datasource com o id e as tags informados.
+func (g *Grant) Covers(datasourceId string, tags []string) bool {
+	if g.IsGlobal() {
+		return true
+	}
+	if g.DatasourceId != nil {
+		return datasourceId != "" && *g.DatasourceId == datasourceId
+	}
+	for _, tag := range tags {
+		if tag == *g.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Grant) Allows(required Permission) bool {
+	for _, permission := range g.Permissions {
+		if permission == required {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package entity
+
+type Permission string
+
+var (
+	PermDatasourceRead  Permission = "datasource:read"
+	PermDatasourceWrite Permission = "datasource:write"
+	PermBackupRead      Permission = "backup:read"
+	PermBackupCreate    Permission = "backup:create"
+	PermBackupRestore   Permission = "backup:restore"
+	PermBackupDelete    Permission = "backup:delete"
+	PermAdmin           Permission = "admin"
+)
+
+var AllPermissions = []Permission{
+	PermDatasourceRead,
+	PermDatasourceWrite,
+	PermBackupRead,
+	PermBackupCreate,
+	PermBackupRestore,
+	PermBackupDelete,
+	PermAdmin,
+}
+
+func (p Permission) IsValid() bool {
+	for _, permission := range AllPermissions {
+		if permission == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredScope retorna o escopo de chave de API necessário para exercer a permissão.
+func (p Permission) RequiredScope() Scope {
+	switch p {
+	case PermDatasourceRead, PermBackupRead:
+		return ScopeRead
+	case PermBackupCreate:
+		return ScopeBackup
+	case PermBackupRestore:
+		return ScopeRestore
+	default:
+		return ScopeAdmin
+	}
+}
+
+// PermissionsForScopes retorna as permissões concedidas por um conjunto de escopos.
+func PermissionsForScopes(scopes []Scope) []Permission {
+	permissions := make([]Permission, 0, len(AllPermissions))
+	for _, permission := range AllPermissions {
+		for _, scope := range scopes {
+			if scope == ScopeAdmin || scope == permission.RequiredScope() {
+				permissions = append(permissions, permission)
+				break
+			}
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,101 @@
+package entity
+
+import "strings"
+
+type PrincipalKind string
+
+var (
+	PrincipalApiKey    PrincipalKind = "api_key"
+	PrincipalBootstrap PrincipalKind = "bootstrap"
+	PrincipalOIDC      PrincipalKind = "oidc"
+)
+
+// Principal representa quem está realizando a requisição autenticada.
+//
+// Os escopos funcionam como teto do que a credencial pode fazer; as concessões (grants)
+// definem em quais datasources cada permissão pode ser exercida.
+type Principal struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Email  string        `json:"email,omitempty"`
+	Kind   PrincipalKind `json:"kind"`
+	UserId *string       `json:"user_id"`
+	Scopes []Scope       `json:"scopes"`
+	Grants []Grant       `json:"grants"`
+}
+
+// NewScopedPrincipal cria um principal sem usuário vinculado, com uma concessão global
+// equivalente às permissões dos escopos informados.
+func NewScopedPrincipal(id, name string, kind PrincipalKind, scopes []Scope) Principal {
+	return Principal{
+		ID:     id,
+		Name:   name,
+		Kind:   kind,
+		Scopes: scopes,
+		Grants: []Grant{{Permissions: PermissionsForScopes(scopes)}},
+	}
+}
+
+// Identity retorna um identificador estável de quem está por trás do principal: o usuário vinculado,
+// quando houver, ou a própria credencial. Duas chaves do mesmo usuário possuem a mesma identidade.
+func (p *Principal) Identity() string {
+	if p.UserId != nil {
+		return "user:" + *p.UserId
+	}
+	return string(p.Kind) + ":" + p.ID
+}
+
+// IsUserIdentity indica se a identidade informada (ver Identity) é de um usuário cadastrado, e não de uma
+// credencial sem usuário vinculado.
+func IsUserIdentity(identity string) bool {
+	return strings.HasPrefix(identity, "user:")
+}
+
+// HasScope indica se o principal possui o escopo informado. O escopo admin concede todos os demais.
+func (p *Principal) HasScope(required Scope) bool {
+	for _, scope := range p.Scopes {
+		if scope == required || scope == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Can indica se o principal pode exercer a permissão sobre o datasource com o id e as tags informados.
+// Com datasourceId vazio e sem tags, apenas concessões globais são consideradas.
+func (p *Principal) Can(permission Permission, datasourceId string, tags []string) bool {
+	if !p.HasScope(permission.RequiredScope()) {
+		return false
+	}
+	for _, grant := range p.Grants {
+		if grant.Allows(permission) && grant.Covers(datasourceId, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAny indica se o principal pode exercer a permissão em ao menos um datasource.
+// A permissão admin só é considerada quando concedida globalmente.
+func (p *Principal) CanAny(permission Permission) bool {
+	if !p.HasScope(permission.RequiredScope()) {
+		return false
+	}
+	for _, grant := range p.Grants {
+		if grant.Allows(permission) && (permission != PermAdmin || grant.IsGlobal()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions retorna as permissões que o principal pode exercer em ao menos um datasource.
+func (p *Principal) Permissions() []Permission {
+	permissions := make([]Permission, 0, len(AllPermissions))
+	for _, permission := range AllPermissions {
+		if p.CanAny(permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+func NewRole(name, description string, permissions []Permission) (*Role, error) {
+	role := &Role{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+	}
+	if err := role.Validate(); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *Role) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("o nome da role é obrigatório")
+	}
+	if len(r.Permissions) == 0 {
+		return fmt.Errorf("a role deve possuir ao menos uma permissão")
+	}
+	for _, permission := range r.Permissions {
+		if !permission.IsValid() {
+			return fmt.Errorf("permissão inválida: %s", permission)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package entity
+
+type Scope string
+
+var (
+	ScopeRead    Scope = "read"
+	ScopeBackup  Scope = "backup"
+	ScopeRestore Scope = "restore"
+	ScopeAdmin   Scope = "admin"
+)
+
+var AllScopes = []Scope{ScopeRead, ScopeBackup, ScopeRestore, ScopeAdmin}
+
+func (s Scope) IsValid() bool {
+	for _, scope := range AllScopes {
+		if scope == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewUser(name, email string, enabled bool) (*User, error) {
+	user := &User{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Email:     email,
+		Enabled:   enabled,
+		CreatedAt: time.Now(),
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *User) Validate() error {
+	if strings.TrimSpace(u.Name) == "" {
+		return fmt.Errorf("o nome do usuário é obrigatório")
+	}
+	if _, err := mail.ParseAddress(u.Email); err != nil {
+		return fmt.Errorf("e-mail inválido: %s", u.Email)
+	}
+	return nil
+}
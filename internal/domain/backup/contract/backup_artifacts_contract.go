@@ -0,0 +1,27 @@
+package contract
+
+import (
+	"io"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+// IBackupArtifacts gerencia os arquivos de backup expostos via HTTP (download e importação).
+type IBackupArtifacts interface {
+	// Import grava o arquivo enviado no diretório de backups, valida o conteúdo e o registra no catálogo
+	// com o trigger import. Retorna entity.ErrInvalidBackupFile se o arquivo não for um backup válido.
+	Import(ds entity.Datasource, fileName string, r io.Reader) (entity.Backup, error)
+
+	// Checksum retorna o backup com o SHA-256 do arquivo, calculando-o e salvando-o se ainda não houver.
+	Checksum(backup entity.Backup) (entity.Backup, error)
+}
+
+// IDownloadSigner gera e valida as assinaturas das URLs de download de curta duração.
+type IDownloadSigner interface {
+	// Sign assina o download do backup, retornando a assinatura e a data de expiração.
+	Sign(backupId string) (string, time.Time)
+
+	// Verify valida a assinatura e a expiração (unix, em segundos) de um download.
+	Verify(backupId, expires, signature string) bool
+}
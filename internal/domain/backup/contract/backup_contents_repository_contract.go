@@ -0,0 +1,9 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+type IBackupContentsRepository interface {
+	GetBackupContents(backupId string) (entity.BackupContents, error)
+	// SaveBackupContents grava (ou substitui) o conteúdo calculado de um backup.
+	SaveBackupContents(contents entity.BackupContents) error
+}
@@ -0,0 +1,11 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+type IBackupProfileRepository interface {
+	GetBackupProfiles() ([]entity.BackupProfile, error)
+	GetBackupProfile(entityID string) (entity.BackupProfile, error)
+	CreateBackupProfile(entity entity.BackupProfile) error
+	UpdateBackupProfile(entity entity.BackupProfile) error
+	DeleteBackupProfile(entityID string) error
+}
@@ -1,36 +1,75 @@
 package contract
 
-import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+import (
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/pgdump"
+)
 
 type Mode string
 
 const (
-	Custom Mode = "c"
-	Plain  Mode = "p"
+	Custom    Mode = "c"
+	Plain     Mode = "p"
+	Tar       Mode = "t"
+	Directory Mode = "d"
 )
 
+// ModeOf retorna o modo do pg_dump correspondente ao formato de backup do datasource.
+func ModeOf(format entity.BackupFormat) Mode {
+	switch format {
+	case entity.BackupFormatCustom:
+		return Custom
+	case entity.BackupFormatTar:
+		return Tar
+	case entity.BackupFormatDirectory:
+		return Directory
+	default:
+		return Plain
+	}
+}
+
+// BackupFormat retorna o formato de backup correspondente ao modo do pg_dump.
+func (m Mode) BackupFormat() entity.BackupFormat {
+	switch m {
+	case Custom:
+		return entity.BackupFormatCustom
+	case Tar:
+		return entity.BackupFormatTar
+	case Directory:
+		return entity.BackupFormatDirectory
+	default:
+		return entity.BackupFormatPlain
+	}
+}
+
 // IBackupService define as operações essenciais de backup e restauração para bancos de dados.
 // Implementações podem suportar diferentes motores como PostgreSQL, MySQL, etc.
 type IBackupService interface {
-	// TestConnection testa a conectividade com o banco de dados informado.
+	// TestConnection testa a conectividade com o banco de dados informado e identifica a versão do servidor.
 	//
 	// Parâmetros:
 	// - ds: informações de conexão com o banco.
 	//
 	// Retorna:
-	// - Um erro caso a conexão falhe, ou nil se for bem-sucedida.
-	TestConnection(ds entity.Datasource) error
+	// - As versões do servidor e dos binários cliente selecionados para ele.
+	// - Um erro caso a conexão falhe ou não haja binários compatíveis com o servidor, ou nil se for bem-sucedida.
+	TestConnection(ds entity.Datasource) (entity.PgVersions, error)
 
 	// Backup realiza o backup completo do banco de dados no formato especificado.
 	//
-	// O backup é sempre gerado no diretório "./backups" e compactado com Gzip.
+	// O backup é sempre gerado no diretório "./backups" como um único arquivo, com o pg_dump da versão major
+	// do servidor ou, sem ela, da versão mais próxima acima.
 	//
 	// Parâmetros:
 	// - ds: informações de conexão com o banco.
 	// - outputFile: nome base do arquivo de destino (sem extensão).
-	// - format: modo de saída (Plain ou Custom). A extensão final será:
-	//     - Plain  → .sql.gz
-	//     - Custom → .backup.gz
+	// - format: modo de saída. A extensão final será:
+	//     - Plain     → .sql.gz
+	//     - Custom    → .backup.gz
+	//     - Tar       → .tar.gz
+	//     - Directory → .dir.tar (diretório do pg_dump empacotado; -j com ds.BackupJobs)
+	//
+	// O conteúdo é restrito por ds.DumpFilter (schemas e tabelas incluídos ou excluídos).
 	//
 	// Retorna:
 	// - A saída do comando pg_dump.
@@ -45,17 +84,61 @@ type IBackupService interface {
 	// - .sql.gz        → descompacta e executa psql
 	// - .backup        → executa pg_restore
 	// - .backup.gz     → descompacta e executa pg_restore
+	// - .tar, .tar.gz  → descompacta, se necessário, e executa pg_restore
+	// - .dir.tar       → extrai o diretório e executa pg_restore
 	//
-	// Antes da restauração, o banco é limpo com ClearDatabase.
+	// Nos formatos custom e directory, o pg_restore usa -j ds.BackupJobs quando maior que 1.
+	//
+	// Antes da restauração, o banco é limpo com ClearDatabase, exceto com scope.SkipClear. Os donos dos
+	// objetos são descartados (--no-owner), exceto com scope.KeepOwners.
+	//
+	// Parâmetros:
+	// - ds: informações de conexão com o banco de destino.
+	// - inputFile: arquivo de backup.
+	// - dumpVersion: versão do pg_dump que gerou o arquivo (Backup.ClientVersion); vazia quando desconhecida.
+	// - scope: schemas e tabelas a restaurar e modos data_only/schema_only (exceto no formato plain).
 	//
 	// Retorna:
 	// - A saída do comando de restauração.
 	// - Um erro, caso o processo falhe.
-	Restore(ds entity.Datasource, inputFile string) (string, error)
+	Restore(ds entity.Datasource, inputFile, dumpVersion string, scope entity.RestoreScope) (string, error)
+
+	// ListContents retorna a tabela de conteúdo (pg_restore --list) de um backup nos formatos custom, tar ou directory.
+	ListContents(inputFile string) (pgdump.TOC, error)
+
+	// Contents calcula o resumo do conteúdo de um backup, sem o BackupId.
+	Contents(inputFile string) (entity.BackupContents, error)
+
+	// OpenTableData abre em fluxo os dados de uma tabela ("schema.tabela" ou "tabela") sem restaurar o backup.
+	// Retorna pgdump.ErrTableNotFound se o backup não contém os dados da tabela.
+	OpenTableData(inputFile string, table string) (*pgdump.TableReader, error)
+
+	// DumpGlobals realiza o backup dos objetos globais do servidor (roles e tablespaces) com
+	// pg_dumpall --globals-only, que o pg_dump não inclui.
+	//
+	// Parâmetros:
+	// - ds: informações de conexão com o servidor; o banco é ignorado.
+	// - outputFile: arquivo de destino, com a extensão .globals.sql.gz.
+	//
+	// Retorna:
+	// - A saída do comando pg_dumpall.
+	// - Arquivo gerado.
+	// - Um erro, caso a execução falhe ou a compactação não seja concluída.
+	DumpGlobals(ds entity.Datasource, outputFile string) (string, string, error)
+
+	// RestoreGlobals executa um arquivo gerado por DumpGlobals no servidor do datasource. Roles e
+	// tablespaces que já existem geram alertas na saída, sem interromper a restauração.
+	//
+	// Retorna a saída do psql ou um erro, caso o arquivo não exista ou a execução falhe.
+	RestoreGlobals(ds entity.Datasource, inputFile string) (string, error)
 
 	// ClearDatabase remove todos os schemas do banco, exceto os padrões, e recria o schema "public".
 	ClearDatabase(ds entity.Datasource) error
 
+	// ListDatabases lista os bancos do servidor que aceitam conexões, exceto os templates, com o tamanho de cada um.
+	// A conexão usa ds.Database, normalmente o banco postgres.
+	ListDatabases(ds entity.Datasource) ([]entity.ServerDatabase, error)
+
 	// CreateDatabase cria um novo banco de dados com o nome informado.
 	CreateDatabase(ds entity.Datasource) error
 
@@ -0,0 +1,12 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+type IBlackoutWindowRepository interface {
+	// GetBlackoutWindows retorna as janelas de bloqueio do datasource informado.
+	GetBlackoutWindows(datasourceId string) ([]entity.BlackoutWindow, error)
+	GetBlackoutWindow(entityID string) (entity.BlackoutWindow, error)
+	CreateBlackoutWindow(entity entity.BlackoutWindow) error
+	UpdateBlackoutWindow(entity entity.BlackoutWindow) error
+	DeleteBlackoutWindow(entityID string) error
+}
@@ -3,5 +3,10 @@ package contract
 import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
 
 type ICommand interface {
+	// Command retorna a função de backup a ser agendada ou executada em segundo plano.
 	Command(ds entity.Datasource, trigger entity.BackupTrigger) func()
+	// Run executa o backup de forma síncrona e retorna o registro gerado.
+	Run(ds entity.Datasource, trigger entity.BackupTrigger) (entity.Backup, error)
+	// Skip registra um backup agendado ignorado pela janela de bloqueio informada.
+	Skip(ds entity.Datasource, window entity.BlackoutWindow) (entity.Backup, error)
 }
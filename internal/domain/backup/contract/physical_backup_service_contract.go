@@ -0,0 +1,38 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+// IPhysicalBackupService define o backup físico de um servidor inteiro e a sua restauração em um diretório
+// de dados. É um motor separado de IBackupService: o backup não pode ser lido pelo pg_restore.
+type IPhysicalBackupService interface {
+	// BaseBackup realiza o backup físico do servidor do datasource.
+	//
+	// Parâmetros:
+	// - ds: informações de conexão com o servidor (o usuário precisa do atributo REPLICATION).
+	// - outputFile: arquivo de destino, com a extensão .base.tar.
+	//
+	// Retorna:
+	// - A saída do comando pg_basebackup.
+	// - Arquivo de backup gerado.
+	// - Um erro, caso a execução falhe ou o empacotamento não seja concluído.
+	BaseBackup(ds entity.Datasource, outputFile string) (string, string, error)
+
+	// RestoreDataDirectory extrai um backup físico em um novo diretório de dados, que pode ser iniciado
+	// com `pg_ctl -D <diretório> start` por binários da mesma versão major do servidor de origem.
+	//
+	// Parâmetros:
+	// - inputFile: arquivo de backup .base.tar.
+	// - name: nome do diretório, criado dentro do diretório de restaurações físicas.
+	//
+	// Retorna:
+	// - O caminho do diretório de dados criado.
+	// - Um resumo da restauração.
+	// - Um erro, caso o diretório já exista ou a extração falhe.
+	RestoreDataDirectory(inputFile string, name string) (string, string, error)
+
+	// ConfigureRecovery configura um diretório de dados criado por RestoreDataDirectory para a recuperação até
+	// um ponto no tempo (PITR), aplicando os WAL arquivados do datasource ao iniciar o servidor.
+	//
+	// Retorna um resumo da configuração ou um erro, caso o alvo seja inválido ou a gravação falhe.
+	ConfigureRecovery(dataDir string, datasourceId string, target entity.RecoveryTarget) (string, error)
+}
@@ -0,0 +1,13 @@
+package contract
+
+import (
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type IRestoreRepository interface {
+	GetRestores(filter dto.RestoreFilter) ([]entity.Restore, error)
+	GetRestore(entityID string) (entity.Restore, error)
+	CreateRestore(entity entity.Restore) error
+	UpdateRestore(entity entity.Restore) error
+}
@@ -0,0 +1,18 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type IRestoreRequestRepository interface {
+	GetRestoreRequests(status *entity.RestoreRequestStatus) ([]entity.RestoreRequest, error)
+	GetRestoreRequest(entityID string) (entity.RestoreRequest, error)
+	CreateRestoreRequest(entity entity.RestoreRequest) error
+	// UpdateRestoreRequest grava a decisão de uma solicitação ainda pendente. Retorna ErrRestoreRequestNotPending
+	// quando ela já foi decidida ou expirada.
+	UpdateRestoreRequest(entity entity.RestoreRequest) error
+	// ExpireRestoreRequests marca como expiradas as solicitações pendentes com prazo anterior a now.
+	ExpireRestoreRequests(now time.Time) error
+}
@@ -0,0 +1,27 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+// IRestoreRunner executa a restauração de um backup em um datasource, registrando e notificando o resultado.
+type IRestoreRunner interface {
+	// CheckScope valida a restauração seletiva contra a tabela de conteúdo do backup: os schemas e
+	// tabelas informados precisam existir nele. Erros de validação envolvem entity.ErrInvalidRestoreOptions.
+	CheckScope(backup entity.Backup, scope entity.RestoreScope) error
+
+	// Run registra a restauração e a executa em segundo plano. O andamento fica disponível no registro retornado.
+	Run(restore entity.Restore, backup entity.Backup, ds entity.Datasource) (entity.Restore, error)
+
+	// RunNewDatabase cria o banco options.TargetDatabase no servidor do datasource, registra-o como
+	// banco temporário e inicia a restauração nele em segundo plano. O datasource original não é alterado.
+	RunNewDatabase(restore entity.Restore, backup entity.Backup, ds entity.Datasource, options entity.RestoreOptions) (entity.Restore, entity.TemporaryDatabase, error)
+
+	// RunDataDirectory extrai um backup físico em um novo diretório de dados (options.DataDirectory) em segundo
+	// plano. Nenhum banco ou datasource é alterado; ds é apenas o datasource de origem do backup.
+	RunDataDirectory(restore entity.Restore, backup entity.Backup, ds entity.Datasource, options entity.RestoreOptions) (entity.Restore, error)
+}
+
+// ITemporaryDatabaseCleaner remove bancos temporários criados por restaurações no modo new_database.
+type ITemporaryDatabaseCleaner interface {
+	// Drop remove o banco e o datasource temporário cadastrado para ele, retornando o registro atualizado.
+	Drop(database entity.TemporaryDatabase) (entity.TemporaryDatabase, error)
+}
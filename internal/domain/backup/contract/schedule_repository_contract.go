@@ -0,0 +1,12 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+type IScheduleRepository interface {
+	// GetSchedules retorna os agendamentos do datasource informado, ou de todos os datasources quando nil.
+	GetSchedules(datasourceId *string) ([]entity.Schedule, error)
+	GetSchedule(entityID string) (entity.Schedule, error)
+	CreateSchedule(entity entity.Schedule) error
+	UpdateSchedule(entity entity.Schedule) error
+	DeleteSchedule(entityID string) error
+}
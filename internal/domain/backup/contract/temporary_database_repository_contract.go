@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type ITemporaryDatabaseRepository interface {
+	GetTemporaryDatabases() ([]entity.TemporaryDatabase, error)
+	GetTemporaryDatabase(entityID string) (entity.TemporaryDatabase, error)
+	// GetExpiredTemporaryDatabases retorna os bancos ainda não removidos que expiraram até now.
+	GetExpiredTemporaryDatabases(now time.Time) ([]entity.TemporaryDatabase, error)
+	CreateTemporaryDatabase(entity entity.TemporaryDatabase) error
+	UpdateTemporaryDatabase(entity entity.TemporaryDatabase) error
+}
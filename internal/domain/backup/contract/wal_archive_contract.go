@@ -0,0 +1,15 @@
+package contract
+
+import (
+	"io"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+// IWalArchive recebe os arquivos de WAL enviados pelo archive_command do servidor de um datasource.
+type IWalArchive interface {
+	// Archive grava o arquivo compactado e o registra no catálogo. Um reenvio com o mesmo conteúdo retorna o
+	// registro existente com created falso; com conteúdo diferente, entity.ErrWalSegmentConflict. Nomes que não
+	// são de WAL retornam entity.ErrInvalidWalFileName.
+	Archive(ds entity.Datasource, fileName string, r io.Reader) (segment entity.WalSegment, created bool, err error)
+}
@@ -0,0 +1,12 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+
+type IWalSegmentRepository interface {
+	// GetWalSegments retorna os arquivos de WAL arquivados do datasource, em ordem de arquivamento.
+	GetWalSegments(datasourceId string) ([]entity.WalSegment, error)
+	GetWalSegment(datasourceId, fileName string) (entity.WalSegment, error)
+	// GetLatestWalSegment retorna o último arquivo de WAL arquivado do datasource.
+	GetLatestWalSegment(datasourceId string) (entity.WalSegment, error)
+	CreateWalSegment(entity entity.WalSegment) error
+}
@@ -1,5 +1,51 @@
 package dto
 
+import "time"
+
 type CreateBackupDto struct {
 	DatasourceId string `json:"datasource_id"`
 }
+
+type RestoreBackupDto struct {
+	// Reason é exibido aos aprovadores quando o datasource de destino é protegido.
+	Reason string `json:"reason"`
+	// ExpiresInMinutes define o prazo para aprovação; zero para não expirar.
+	ExpiresInMinutes int `json:"expires_in_minutes"`
+	// Mode define onde o backup é restaurado: "overwrite" (padrão), "new_database" ou "data_directory"
+	// (obrigatório para backups físicos).
+	Mode string `json:"mode"`
+	// DatabaseName sobrescreve o nome do banco criado no modo new_database.
+	DatabaseName string `json:"database_name"`
+	// RegisterDatasource cadastra o banco criado no modo new_database como datasource temporário.
+	RegisterDatasource bool `json:"register_datasource"`
+	// DataDirectory sobrescreve o nome do diretório de dados criado no modo data_directory.
+	DataDirectory string `json:"data_directory"`
+	// RecoveryTargetTime e RecoveryTargetLSN recuperam um backup físico até um ponto no tempo (PITR) no modo
+	// data_directory, aplicando os WAL arquivados do datasource. Apenas um dos dois pode ser informado.
+	RecoveryTargetTime *time.Time `json:"recovery_target_time"`
+	RecoveryTargetLSN  string     `json:"recovery_target_lsn"`
+	// TTLHours define em quantas horas o banco criado no modo new_database é removido; zero usa o padrão.
+	TTLHours int `json:"ttl_hours"`
+	// Restauração seletiva (apenas backups no formato custom). Tabelas aceitam "schema.tabela" ou "tabela".
+	Schemas        []string `json:"schemas"`
+	ExcludeSchemas []string `json:"exclude_schemas"`
+	Tables         []string `json:"tables"`
+	ExcludeTables  []string `json:"exclude_tables"`
+	DataOnly       bool     `json:"data_only"`
+	SchemaOnly     bool     `json:"schema_only"`
+	// SkipClear não limpa o banco de destino antes da restauração.
+	SkipClear bool `json:"skip_clear"`
+	// SkipGlobals não restaura os roles e tablespaces gravados junto do backup.
+	SkipGlobals bool `json:"skip_globals"`
+}
+
+type DecideRestoreRequestDto struct {
+	Comment string `json:"comment"`
+}
+
+// RestoreFilter restringe a listagem de restaurações. Campos vazios não filtram.
+type RestoreFilter struct {
+	DatasourceId string
+	BackupId     string
+	Status       string
+}
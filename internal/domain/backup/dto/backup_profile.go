@@ -0,0 +1,63 @@
+package dto
+
+type RetentionDto struct {
+	// KeepLast mantém apenas os N backups mais recentes; zero não limita.
+	KeepLast int `json:"keep_last"`
+	// MaxAgeDays remove os backups com mais de N dias; zero não limita.
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+// BackupOptionsDto são as opções de backup de perfis e agendamentos.
+type BackupOptionsDto struct {
+	// BackupFormat é o formato do pg_dump: plain (padrão), custom, tar, directory ou physical.
+	BackupFormat string `json:"backup_format"`
+	BackupJobs   int    `json:"backup_jobs"`
+	// CompressionLevel é o nível de compressão do gzip (1 a 9); zero usa o padrão.
+	CompressionLevel int           `json:"compression_level"`
+	DumpFilter       DumpFilterDto `json:"dump_filter"`
+	// SchemaOnly gera backups somente da estrutura, sem os dados.
+	SchemaOnly bool         `json:"schema_only"`
+	Retention  RetentionDto `json:"retention"`
+	// TimeoutMinutes é o tempo máximo de cada backup; zero usa o padrão.
+	TimeoutMinutes int `json:"timeout_minutes"`
+}
+
+type CreateBackupProfileDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CronExpr    string `json:"cron_expr"`
+	// TimeZone é o fuso horário IANA do cron (ex: America/Sao_Paulo); vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+	BackupOptionsDto
+}
+
+type UpdateBackupProfileDto struct {
+	CreateBackupProfileDto
+}
+
+type CreateScheduleDto struct {
+	Name     string `json:"name"`
+	CronExpr string `json:"cron_expr"`
+	// TimeZone é o fuso horário IANA do cron (ex: America/Sao_Paulo); vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+	Enabled  bool   `json:"enabled"`
+	BackupOptionsDto
+}
+
+type UpdateScheduleDto struct {
+	CreateScheduleDto
+}
+
+// CreateBlackoutWindowDto define uma janela recorrente: cada ocorrência começa nos horários de CronExpr e dura
+// DurationMinutes. Action é skip (padrão) ou defer.
+type CreateBlackoutWindowDto struct {
+	Name            string `json:"name"`
+	CronExpr        string `json:"cron_expr"`
+	DurationMinutes int    `json:"duration_minutes"`
+	TimeZone        string `json:"time_zone"`
+	Action          string `json:"action"`
+}
+
+type UpdateBlackoutWindowDto struct {
+	CreateBlackoutWindowDto
+}
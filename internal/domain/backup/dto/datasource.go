@@ -4,6 +4,17 @@ type CronExprDto struct {
 	CronExpr    string `json:"cron_expr"`
 	Description string `json:"description"`
 	Enabled     bool   `json:"enabled"`
+	// TimeZone é o fuso horário IANA do cron (ex: America/Sao_Paulo); vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+}
+
+// DumpFilterDto aceita os padrões do pg_dump, como "audit", "public.logs" ou "log_*".
+type DumpFilterDto struct {
+	Schemas          []string `json:"schemas"`
+	ExcludeSchemas   []string `json:"exclude_schemas"`
+	Tables           []string `json:"tables"`
+	ExcludeTables    []string `json:"exclude_tables"`
+	ExcludeTableData []string `json:"exclude_table_data"`
 }
 
 type CreateDatasourceDto struct {
@@ -14,8 +25,39 @@ type CreateDatasourceDto struct {
 	Password string      `json:"password"`
 	SSLMode  string      `json:"ssl_mode"`
 	Cron     CronExprDto `json:"cron"`
+	Tags     []string    `json:"tags"`
+	// Protected exige aprovação de um segundo usuário para restaurações no datasource.
+	Protected bool `json:"protected"`
+	// BackupFormat é o formato do pg_dump: plain (padrão), custom, tar ou directory.
+	BackupFormat string `json:"backup_format"`
+	// BackupJobs é o número de jobs paralelos (formatos custom e directory).
+	BackupJobs int `json:"backup_jobs"`
+	// DumpFilter restringe os schemas e tabelas incluídos nos backups.
+	DumpFilter DumpFilterDto `json:"dump_filter"`
+	// IncludeGlobals inclui os roles e tablespaces do servidor (pg_dumpall --globals-only) em cada backup.
+	IncludeGlobals bool `json:"include_globals"`
+	// ProfileId é o perfil de backup do datasource; o cron e as opções de backup do perfil substituem os informados.
+	ProfileId *string `json:"profile_id"`
 }
 
 type UpdateDatasourceDto struct {
 	CreateDatasourceDto
 }
+
+// DiscoverDatabasesDto são as credenciais de um servidor para a descoberta dos seus bancos. Database é o banco
+// usado na conexão (padrão postgres).
+type DiscoverDatabasesDto struct {
+	Host     string `json:"host"`
+	Database string `json:"database"`
+	Port     int32  `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	SSLMode  string `json:"ssl_mode"`
+}
+
+// RegisterDatabasesDto cadastra um datasource para cada banco em Databases. As credenciais, o cron e as opções
+// de backup são compartilhados por todos; Database é apenas o banco usado na descoberta.
+type RegisterDatabasesDto struct {
+	CreateDatasourceDto
+	Databases []string `json:"databases"`
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// ContentObject é um objeto contido em um backup.
+type ContentObject struct {
+	Type   string `json:"type"`
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner,omitempty"`
+	// DataSize é o tamanho aproximado dos dados (texto do COPY, sem compressão), apenas em tabelas.
+	DataSize int64 `json:"data_size,omitempty"`
+}
+
+// BackupContents é o resumo do conteúdo de um backup, calculado uma vez e mantido em cache.
+type BackupContents struct {
+	BackupId  string          `json:"backup_id"`
+	Format    BackupFormat    `json:"format"`
+	Schemas   []string        `json:"schemas"`
+	Tables    []ContentObject `json:"tables"`
+	Views     []ContentObject `json:"views"`
+	Functions []ContentObject `json:"functions"`
+	Indexes   []ContentObject `json:"indexes"`
+	Sequences []ContentObject `json:"sequences"`
+	// ObjectCounts conta os objetos por tipo, incluindo os não listados acima (constraints, triggers, extensões...).
+	ObjectCounts map[string]int `json:"object_counts"`
+	DataSize     int64          `json:"data_size"`
+	ComputedAt   time.Time      `json:"computed_at"`
+}
+
+// NewBackupContents agrupa os objetos do backup por tipo. O tamanho dos dados de cada tabela vem da
+// entrada TABLE DATA correspondente.
+func NewBackupContents(backupId string, format BackupFormat, objects []ContentObject) *BackupContents {
+	contents := &BackupContents{
+		BackupId:     backupId,
+		Format:       format,
+		Schemas:      make([]string, 0),
+		Tables:       make([]ContentObject, 0),
+		Views:        make([]ContentObject, 0),
+		Functions:    make([]ContentObject, 0),
+		Indexes:      make([]ContentObject, 0),
+		Sequences:    make([]ContentObject, 0),
+		ObjectCounts: make(map[string]int),
+		ComputedAt:   time.Now(),
+	}
+
+	schemas := make(map[string]bool)
+	dataSizes := make(map[string]int64)
+	for _, object := range objects {
+		contents.ObjectCounts[object.Type]++
+		if object.Schema != "" {
+			schemas[object.Schema] = true
+		}
+		switch object.Type {
+		case "SCHEMA":
+			schemas[object.Name] = true
+		case "TABLE", "FOREIGN TABLE":
+			contents.Tables = append(contents.Tables, object)
+		case "TABLE DATA":
+			dataSizes[object.Schema+"."+object.Name] += object.DataSize
+			contents.DataSize += object.DataSize
+		case "VIEW", "MATERIALIZED VIEW":
+			contents.Views = append(contents.Views, object)
+		case "FUNCTION", "PROCEDURE", "AGGREGATE":
+			contents.Functions = append(contents.Functions, object)
+		case "INDEX":
+			contents.Indexes = append(contents.Indexes, object)
+		case "SEQUENCE":
+			contents.Sequences = append(contents.Sequences, object)
+		}
+	}
+	for i, table := range contents.Tables {
+		contents.Tables[i].DataSize = dataSizes[table.Schema+"."+table.Name]
+	}
+
+	for schema := range schemas {
+		contents.Schemas = append(contents.Schemas, schema)
+	}
+	sort.Strings(contents.Schemas)
+	return contents
+}
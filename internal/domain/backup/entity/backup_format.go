@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BackupFormat é o formato do arquivo gerado pelo pg_dump.
+type BackupFormat string
+
+var (
+	BackupFormatPlain  BackupFormat = "plain"
+	BackupFormatCustom BackupFormat = "custom"
+	BackupFormatTar    BackupFormat = "tar"
+	// BackupFormatDirectory gera um arquivo por tabela, permitindo dump e restauração paralelos (-j).
+	// O diretório é empacotado em um único arquivo .dir.tar.
+	BackupFormatDirectory BackupFormat = "directory"
+	// BackupFormatPhysical é o backup físico do cluster inteiro feito pelo pg_basebackup (formato tar,
+	// compactado e com os WAL necessários). Não é lido pelo pg_restore: a restauração gera um diretório de dados.
+	BackupFormatPhysical BackupFormat = "physical"
+)
+
+// ErrInvalidDumpOptions indica opções de dump inválidas no datasource.
+var ErrInvalidDumpOptions = errors.New("opções de dump inválidas")
+
+// ErrPhysicalBackup indica uma operação de backup lógico (pg_restore, conteúdo, exportação) sobre um backup físico.
+var ErrPhysicalBackup = errors.New("backups físicos (pg_basebackup) só podem ser restaurados como diretório de dados")
+
+// Extension retorna a extensão do arquivo de backup gerado no formato.
+func (f BackupFormat) Extension() string {
+	switch f {
+	case BackupFormatCustom:
+		return ".backup.gz"
+	case BackupFormatTar:
+		return ".tar.gz"
+	case BackupFormatDirectory:
+		return ".dir.tar"
+	case BackupFormatPhysical:
+		return ".base.tar"
+	default:
+		return ".sql.gz"
+	}
+}
+
+// UsesPgRestore indica se o backup é restaurado com pg_restore (custom, tar e directory).
+func (f BackupFormat) UsesPgRestore() bool {
+	return f == BackupFormatCustom || f == BackupFormatTar || f == BackupFormatDirectory
+}
+
+// IsPhysical indica se o formato é o backup físico do pg_basebackup.
+func (f BackupFormat) IsPhysical() bool {
+	return f == BackupFormatPhysical
+}
+
+// SupportsJobs indica se o pg_restore aceita -j no formato; o pg_dump aceita apenas no directory.
+func (f BackupFormat) SupportsJobs() bool {
+	return f == BackupFormatCustom || f == BackupFormatDirectory
+}
+
+// ValidateDumpOptions valida o formato e o número de jobs paralelos de um datasource.
+func ValidateDumpOptions(format BackupFormat, jobs int) error {
+	switch format {
+	case BackupFormatPlain, BackupFormatCustom, BackupFormatTar, BackupFormatDirectory, BackupFormatPhysical:
+	default:
+		return fmt.Errorf("%w: formato de backup inválido %q (use plain, custom, tar, directory ou physical)", ErrInvalidDumpOptions, format)
+	}
+	if jobs < 1 {
+		return fmt.Errorf("%w: o número de jobs deve ser maior que zero", ErrInvalidDumpOptions)
+	}
+	if jobs > 1 && !format.SupportsJobs() {
+		return fmt.Errorf("%w: jobs paralelos exigem o formato custom ou directory", ErrInvalidDumpOptions)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBackupProfile indica um perfil de backup inválido.
+var ErrInvalidBackupProfile = errors.New("perfil de backup inválido")
+
+// Retention define quais backups agendados e manuais concluídos são removidos após um novo backup. Zero desativa
+// o critério.
+type Retention struct {
+	// KeepLast mantém apenas os N backups mais recentes.
+	KeepLast int `json:"keep_last"`
+	// MaxAgeDays remove os backups iniciados há mais de N dias.
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+func (r Retention) IsEmpty() bool {
+	return r.KeepLast == 0 && r.MaxAgeDays == 0
+}
+
+// Expired retorna os backups a remover. Apenas backups concluídos dos triggers cron e manual são considerados;
+// snapshots pre-restore e importações são mantidos. O backup concluído mais recente nunca é removido.
+func (r Retention) Expired(backups []Backup, now time.Time) []Backup {
+	if r.IsEmpty() {
+		return nil
+	}
+	candidates := make([]Backup, 0, len(backups))
+	for _, backup := range backups {
+		if backup.Status == BackupCompleted && backup.StartedAt != nil && (backup.Trigger == BackupCron || backup.Trigger == BackupManual) {
+			candidates = append(candidates, backup)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b Backup) int { return b.StartedAt.Compare(*a.StartedAt) })
+
+	expired := make([]Backup, 0)
+	for i, backup := range candidates {
+		if i == 0 {
+			continue
+		}
+		if (r.KeepLast > 0 && i >= r.KeepLast) || (r.MaxAgeDays > 0 && backup.StartedAt.Before(now.AddDate(0, 0, -r.MaxAgeDays))) {
+			expired = append(expired, backup)
+		}
+	}
+	return expired
+}
+
+// BackupOptions são as opções de execução de um backup, definidas por um perfil de backup.
+type BackupOptions struct {
+	BackupFormat BackupFormat `json:"backup_format"`
+	BackupJobs   int          `json:"backup_jobs"`
+	// CompressionLevel é o nível de compressão do gzip (1 a 9); zero usa o padrão.
+	CompressionLevel int        `json:"compression_level"`
+	DumpFilter       DumpFilter `json:"dump_filter"`
+	// SchemaOnly gera apenas a estrutura do banco, sem os dados (pg_dump --schema-only).
+	SchemaOnly bool      `json:"schema_only"`
+	Retention  Retention `json:"retention"`
+	// TimeoutMinutes é o tempo máximo do dump; zero usa o padrão do serviço de backup.
+	TimeoutMinutes int `json:"timeout_minutes"`
+}
+
+// Validate valida as opções. Formato vazio é plain e jobs zero é um único job, como em Datasource.SetDumpOptions.
+func (o *BackupOptions) Validate() error {
+	if o.BackupFormat == "" {
+		o.BackupFormat = BackupFormatPlain
+	}
+	if o.BackupJobs == 0 {
+		o.BackupJobs = 1
+	}
+	if err := ValidateDumpOptions(o.BackupFormat, o.BackupJobs); err != nil {
+		return err
+	}
+	if o.BackupFormat.IsPhysical() && !o.DumpFilter.IsEmpty() {
+		return fmt.Errorf("%w: o formato physical não aceita filtros de schemas e tabelas", ErrInvalidDumpOptions)
+	}
+	if o.BackupFormat.IsPhysical() && o.SchemaOnly {
+		return fmt.Errorf("%w: o formato physical não aceita backups somente da estrutura", ErrInvalidDumpOptions)
+	}
+	if o.CompressionLevel < 0 || o.CompressionLevel > 9 {
+		return fmt.Errorf("%w: o nível de compressão deve estar entre 1 e 9 (zero usa o padrão)", ErrInvalidBackupProfile)
+	}
+	if o.Retention.KeepLast < 0 || o.Retention.MaxAgeDays < 0 {
+		return fmt.Errorf("%w: a retenção não pode ser negativa", ErrInvalidBackupProfile)
+	}
+	if o.TimeoutMinutes < 0 {
+		return fmt.Errorf("%w: o tempo máximo não pode ser negativo", ErrInvalidBackupProfile)
+	}
+	return nil
+}
+
+// Apply retorna o datasource com as opções aplicadas. O formato physical já contém os objetos globais, por isso
+// IncludeGlobals é desativado.
+func (o BackupOptions) Apply(ds Datasource) Datasource {
+	ds.BackupFormat = o.BackupFormat
+	ds.BackupJobs = o.BackupJobs
+	ds.DumpFilter = o.DumpFilter
+	ds.SchemaOnly = o.SchemaOnly
+	ds.CompressionLevel = o.CompressionLevel
+	ds.Retention = o.Retention
+	ds.BackupTimeout = time.Duration(o.TimeoutMinutes) * time.Minute
+	if o.BackupFormat.IsPhysical() {
+		ds.IncludeGlobals = false
+	}
+	return ds
+}
+
+// BackupProfile reúne o agendamento e as opções de backup compartilhados por vários datasources. Os datasources que
+// referenciam um perfil usam o cron e as opções dele no lugar dos seus, de modo que alterar o perfil altera todos.
+type BackupProfile struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CronExpr    string `json:"cron_expr"`
+	// TimeZone é o fuso horário IANA em que CronExpr é interpretada; vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+	BackupOptions
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewBackupProfile(name, description, cronExpr, timeZone string, options BackupOptions) (*BackupProfile, error) {
+	profile := &BackupProfile{
+		ID:            uuid.New().String(),
+		Name:          name,
+		Description:   description,
+		CronExpr:      cronExpr,
+		TimeZone:      timeZone,
+		BackupOptions: options,
+		CreatedAt:     time.Now(),
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (p *BackupProfile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("%w: o nome é obrigatório", ErrInvalidBackupProfile)
+	}
+	if strings.TrimSpace(p.CronExpr) == "" {
+		return fmt.Errorf("%w: a expressão cron é obrigatória", ErrInvalidBackupProfile)
+	}
+	if err := ValidateTimeZone(p.TimeZone); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBackupProfile, err)
+	}
+	return p.BackupOptions.Validate()
+}
+
+// Apply retorna o datasource com o cron e as opções do perfil. A ativação do agendamento continua sendo a do
+// datasource (Cron.Enabled).
+func (p BackupProfile) Apply(ds Datasource) Datasource {
+	ds = p.BackupOptions.Apply(ds)
+	cron := CronExpr{}
+	if ds.Cron != nil {
+		cron = *ds.Cron
+	}
+	cron.CronExpr = p.CronExpr
+	cron.TimeZone = p.TimeZone
+	ds.Cron = &cron
+	return ds
+}
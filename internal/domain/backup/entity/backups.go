@@ -1,21 +1,32 @@
 package entity
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrInvalidBackupFile indica que o arquivo enviado para importação não é um backup válido.
+var ErrInvalidBackupFile = errors.New("arquivo de backup inválido")
+
 type BackupTrigger string
 type BackupStatus string
 
 var (
 	BackupManual BackupTrigger = "manual"
 	BackupCron   BackupTrigger = "cron"
+	// BackupPreRestore identifica o snapshot de segurança feito antes de uma restauração.
+	BackupPreRestore BackupTrigger = "pre-restore"
+	// BackupImport identifica um arquivo de backup enviado via upload, e não gerado pelo pg_dump do serviço.
+	BackupImport BackupTrigger = "import"
 
 	BackupInitialized BackupStatus = "initialized"
 	BackupCompleted   BackupStatus = "completed"
 	BackupFailed      BackupStatus = "failed"
+	// BackupSkipped registra um backup agendado ignorado por uma janela de bloqueio do datasource.
+	BackupSkipped BackupStatus = "skipped"
 )
 
 type Backup struct {
@@ -26,9 +37,34 @@ type Backup struct {
 	FilePath         string        `json:"file_path"`
 	FileOriginalName string        `json:"file_original_name"`
 	FileSize         int64         `json:"file_size"`
-	StartedAt        *time.Time    `json:"started_at"`
-	FinishedAt       *time.Time    `json:"finished_at"`
-	RestoredAt       *time.Time    `json:"restored_at"`
+	// Checksum é o SHA-256 (hex) do arquivo de backup.
+	Checksum   string     `json:"checksum"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	RestoredAt *time.Time `json:"restored_at"`
+	// DumpFilter é o filtro do datasource efetivamente aplicado neste backup.
+	DumpFilter DumpFilter `json:"dump_filter"`
+	// SchemaOnly indica um backup somente da estrutura, sem os dados.
+	SchemaOnly bool `json:"schema_only"`
+	// ScheduleId é o agendamento do datasource que gerou o backup; nil nos demais backups.
+	ScheduleId *string `json:"schedule_id"`
+	// SkippedBy é a janela de bloqueio que impediu o backup (apenas para o status skipped).
+	SkippedBy *string `json:"skipped_by"`
+	// GlobalsFilePath é o arquivo com os objetos globais do servidor (roles e tablespaces), gerado pelo
+	// pg_dumpall --globals-only quando o datasource inclui os globais. Vazio nos demais backups.
+	GlobalsFilePath string `json:"globals_file_path"`
+	// ServerVersion e ClientVersion são as versões do servidor e do pg_dump (ou pg_basebackup) usados no backup.
+	// Vazias em backups importados.
+	ServerVersion string `json:"server_version"`
+	ClientVersion string `json:"client_version"`
+	// PreRestoreOf é o backup cuja restauração motivou este snapshot (apenas para o trigger pre-restore).
+	PreRestoreOf *string `json:"pre_restore_of"`
+}
+
+// PgVersions são as versões do servidor PostgreSQL e dos binários cliente selecionados para ele.
+type PgVersions struct {
+	Server string
+	Client string
 }
 
 func NewBackup(datasourceId string, trigger BackupTrigger) *Backup {
@@ -46,6 +82,16 @@ func NewBackup(datasourceId string, trigger BackupTrigger) *Backup {
 	}
 }
 
+// IsPhysical indica se o arquivo do backup foi gerado pelo pg_basebackup.
+func (b *Backup) IsPhysical() bool {
+	return strings.HasSuffix(b.FilePath, BackupFormatPhysical.Extension())
+}
+
+func (b *Backup) SetVersions(versions PgVersions) {
+	b.ServerVersion = versions.Server
+	b.ClientVersion = versions.Client
+}
+
 func (b *Backup) SetStartedAt() {
 	now := time.Now()
 	b.StartedAt = &now
@@ -69,6 +115,12 @@ func (b *Backup) SetFailed() {
 	b.Status = BackupFailed
 }
 
+// SetSkipped registra o backup como ignorado pela janela de bloqueio informada.
+func (b *Backup) SetSkipped(windowId string) {
+	b.Status = BackupSkipped
+	b.SkippedBy = &windowId
+}
+
 func (b *Backup) SetInitialized() {
 	b.Status = BackupInitialized
 }
@@ -80,3 +132,11 @@ func (b *Backup) SetTriggerCron() {
 func (b *Backup) SetTriggerManual() {
 	b.Trigger = BackupManual
 }
+
+// Duration retorna o tempo de execução do backup, ou zero se ainda não finalizado.
+func (b *Backup) Duration() time.Duration {
+	if b.StartedAt == nil || b.FinishedAt == nil {
+		return 0
+	}
+	return b.FinishedAt.Sub(*b.StartedAt)
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBlackoutWindow indica uma janela de bloqueio inválida.
+var ErrInvalidBlackoutWindow = errors.New("janela de bloqueio inválida")
+
+type BlackoutAction string
+
+var (
+	// BlackoutSkip ignora os backups agendados durante a janela, registrando-os com o status skipped.
+	BlackoutSkip BlackoutAction = "skip"
+	// BlackoutDefer adia os backups agendados durante a janela para o seu fim.
+	BlackoutDefer BlackoutAction = "defer"
+)
+
+// BlackoutWindow é um período recorrente em que os backups agendados de um datasource não são executados (ex:
+// o processamento de fechamento do mês). Cada ocorrência começa nos horários de CronExpr, no fuso TimeZone, e
+// dura DurationMinutes. Backups manuais não são afetados.
+type BlackoutWindow struct {
+	ID              string         `json:"id"`
+	DatasourceId    string         `json:"datasource_id"`
+	Name            string         `json:"name"`
+	CronExpr        string         `json:"cron_expr"`
+	DurationMinutes int            `json:"duration_minutes"`
+	TimeZone        string         `json:"time_zone"`
+	Action          BlackoutAction `json:"action"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+func NewBlackoutWindow(datasourceId, name, cronExpr string, durationMinutes int, timeZone string, action BlackoutAction) (*BlackoutWindow, error) {
+	window := &BlackoutWindow{
+		ID:              uuid.New().String(),
+		DatasourceId:    datasourceId,
+		Name:            name,
+		CronExpr:        cronExpr,
+		DurationMinutes: durationMinutes,
+		TimeZone:        timeZone,
+		Action:          action,
+		CreatedAt:       time.Now(),
+	}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// Validate valida a janela. Ação vazia é skip.
+func (w *BlackoutWindow) Validate() error {
+	if w.Action == "" {
+		w.Action = BlackoutSkip
+	}
+	if strings.TrimSpace(w.Name) == "" {
+		return fmt.Errorf("%w: o nome é obrigatório", ErrInvalidBlackoutWindow)
+	}
+	if strings.TrimSpace(w.CronExpr) == "" {
+		return fmt.Errorf("%w: a expressão cron do início é obrigatória", ErrInvalidBlackoutWindow)
+	}
+	if w.DurationMinutes <= 0 {
+		return fmt.Errorf("%w: a duração deve ser maior que zero", ErrInvalidBlackoutWindow)
+	}
+	if w.Action != BlackoutSkip && w.Action != BlackoutDefer {
+		return fmt.Errorf("%w: a ação deve ser skip ou defer", ErrInvalidBlackoutWindow)
+	}
+	if err := ValidateTimeZone(w.TimeZone); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBlackoutWindow, err)
+	}
+	return nil
+}
+
+// Duration retorna a duração de cada ocorrência da janela.
+func (w *BlackoutWindow) Duration() time.Duration {
+	return time.Duration(w.DurationMinutes) * time.Minute
+}
+
+// CronSpec retorna a expressão do início da janela no seu fuso horário (ver CronSpec).
+func (w *BlackoutWindow) CronSpec() string {
+	return CronSpec(w.CronExpr, w.TimeZone)
+}
+
+// ValidateTimeZone verifica se o fuso horário é um nome IANA conhecido (ex: America/Sao_Paulo). Vazio é o fuso
+// do servidor.
+func ValidateTimeZone(timeZone string) error {
+	if timeZone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return fmt.Errorf("fuso horário desconhecido: %s", timeZone)
+	}
+	return nil
+}
+
+// CronSpec retorna a expressão cron interpretada no fuso horário informado, com o prefixo CRON_TZ aceito pelo
+// agendador. Sem fuso, a expressão usa o fuso do servidor.
+func CronSpec(cronExpr, timeZone string) string {
+	if timeZone == "" {
+		return cronExpr
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", timeZone, cronExpr)
+}
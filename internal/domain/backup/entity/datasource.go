@@ -1,6 +1,10 @@
 package entity
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/bvaledev/database-backup-management-be/internal/pkg/encryption"
 	"github.com/google/uuid"
 )
@@ -9,6 +13,13 @@ type CronExpr struct {
 	CronExpr    string `json:"cron_expr"`
 	Description string `json:"description"`
 	Enabled     bool   `json:"enabled"`
+	// TimeZone é o fuso horário IANA em que CronExpr é interpretada (ex: America/Sao_Paulo); vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+}
+
+// Spec retorna a expressão no seu fuso horário, como registrada no agendador (ver CronSpec).
+func (c CronExpr) Spec() string {
+	return CronSpec(c.CronExpr, c.TimeZone)
 }
 
 type Datasource struct {
@@ -20,9 +31,31 @@ type Datasource struct {
 	SSLMode  string    `json:"ssl_mode"`
 	Password string    `json:"-"`
 	Cron     *CronExpr `json:"cron"`
+	Tags     []string  `json:"tags"`
+	// Protected exige aprovação de um segundo usuário para restaurações neste datasource.
+	Protected bool `json:"protected"`
+	// BackupFormat é o formato do pg_dump (plain, custom, tar ou directory).
+	BackupFormat BackupFormat `json:"backup_format"`
+	// BackupJobs é o número de jobs paralelos do pg_dump (formato directory) e do pg_restore (custom e directory).
+	BackupJobs int `json:"backup_jobs"`
+	// DumpFilter restringe os schemas e tabelas incluídos nos backups.
+	DumpFilter DumpFilter `json:"dump_filter"`
+	// IncludeGlobals gera, junto de cada backup, o dump dos roles e tablespaces do servidor (pg_dumpall --globals-only).
+	IncludeGlobals bool `json:"include_globals"`
+	// ProfileId é o perfil de backup do datasource, cujo cron e opções substituem os do datasource.
+	ProfileId *string `json:"profile_id"`
+
+	// SchemaOnly, CompressionLevel, Retention e BackupTimeout não são persistidos: vêm do perfil ou do agendamento
+	// (BackupOptions.Apply).
+	SchemaOnly       bool          `json:"-"`
+	CompressionLevel int           `json:"-"`
+	Retention        Retention     `json:"-"`
+	BackupTimeout    time.Duration `json:"-"`
+	// ScheduleId é o agendamento que disparou o backup (Schedule.Apply); nil no cron do próprio datasource.
+	ScheduleId *string `json:"-"`
 }
 
-func NewDatasource(host, database, username, password, sslMode string, port int32, cronExpr, description string, enabled bool) (*Datasource, error) {
+func NewDatasource(host, database, username, password, sslMode string, port int32, cronExpr, description string, enabled bool, tags []string) (*Datasource, error) {
 	id := uuid.New()
 	return &Datasource{
 		ID:       id.String(),
@@ -32,10 +65,70 @@ func NewDatasource(host, database, username, password, sslMode string, port int3
 		Password: password,
 		SSLMode:  sslMode,
 		Port:     port,
-		Cron:     &CronExpr{cronExpr, description, enabled},
+		Cron:     &CronExpr{CronExpr: cronExpr, Description: description, Enabled: enabled},
+		Tags:     normalizeTags(tags),
+
+		BackupFormat: BackupFormatPlain,
+		BackupJobs:   1,
 	}, nil
 }
 
+// SetCronTimeZone define o fuso horário do cron do datasource. Vazio usa o fuso do servidor.
+func (d *Datasource) SetCronTimeZone(timeZone string) error {
+	if err := ValidateTimeZone(timeZone); err != nil {
+		return err
+	}
+	d.Cron.TimeZone = timeZone
+	return nil
+}
+
+// SetDumpOptions define o formato e os jobs paralelos do dump. Formato vazio mantém plain e jobs zero
+// significa um único job.
+func (d *Datasource) SetDumpOptions(format BackupFormat, jobs int) error {
+	if format == "" {
+		format = BackupFormatPlain
+	}
+	if jobs == 0 {
+		jobs = 1
+	}
+	if err := ValidateDumpOptions(format, jobs); err != nil {
+		return err
+	}
+	d.BackupFormat = format
+	d.BackupJobs = jobs
+	return nil
+}
+
+// SetDumpFilter define o filtro do dump. O backup físico copia o cluster inteiro e não aceita filtros,
+// por isso SetDumpOptions deve ser chamado antes.
+func (d *Datasource) SetDumpFilter(filter DumpFilter) error {
+	if d.BackupFormat.IsPhysical() && !filter.IsEmpty() {
+		return fmt.Errorf("%w: o formato physical não aceita filtros de schemas e tabelas", ErrInvalidDumpOptions)
+	}
+	d.DumpFilter = filter
+	return nil
+}
+
+// SameDatabase indica se o datasource aponta para o banco informado do servidor.
+func (d *Datasource) SameDatabase(host string, port int32, database string) bool {
+	return strings.EqualFold(d.Host, host) && d.Port == port && d.Database == database
+}
+
+// SetIncludeGlobals define se os objetos globais do servidor acompanham os backups. O backup físico já
+// contém o cluster inteiro, por isso SetDumpOptions deve ser chamado antes.
+func (d *Datasource) SetIncludeGlobals(include bool) error {
+	if d.BackupFormat.IsPhysical() && include {
+		return fmt.Errorf("%w: o formato physical já inclui os objetos globais do servidor", ErrInvalidDumpOptions)
+	}
+	d.IncludeGlobals = include
+	return nil
+}
+
+// SetTags substitui as tags do datasource, removendo espaços, vazios e duplicados.
+func (d *Datasource) SetTags(tags []string) {
+	d.Tags = normalizeTags(tags)
+}
+
 func (d *Datasource) Encode() (Datasource, error) {
 	datasource := d
 	if d.IsEncoded() {
@@ -66,3 +159,17 @@ func (d *Datasource) IsEncoded() bool {
 	_, err := encryption.Decrypt(d.Password)
 	return err == nil
 }
+
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -0,0 +1,41 @@
+package entity
+
+// DumpFilter restringe o conteúdo dos backups de um datasource. Os nomes aceitam os padrões do pg_dump,
+// como "audit" (schema), "public.logs" e "log_*" (tabelas).
+type DumpFilter struct {
+	// Schemas inclui apenas os schemas informados (pg_dump -n).
+	Schemas []string `json:"schemas,omitempty"`
+	// ExcludeSchemas exclui os schemas informados (pg_dump -N).
+	ExcludeSchemas []string `json:"exclude_schemas,omitempty"`
+	// Tables inclui apenas as tabelas informadas (pg_dump -t).
+	Tables []string `json:"tables,omitempty"`
+	// ExcludeTables exclui as tabelas informadas (pg_dump -T).
+	ExcludeTables []string `json:"exclude_tables,omitempty"`
+	// ExcludeTableData mantém a estrutura das tabelas informadas, sem os dados (pg_dump --exclude-table-data).
+	ExcludeTableData []string `json:"exclude_table_data,omitempty"`
+}
+
+// NewDumpFilter cria um filtro removendo espaços, vazios e duplicados de cada lista.
+func NewDumpFilter(schemas, excludeSchemas, tables, excludeTables, excludeTableData []string) DumpFilter {
+	return DumpFilter{
+		Schemas:          normalizeNames(schemas),
+		ExcludeSchemas:   normalizeNames(excludeSchemas),
+		Tables:           normalizeNames(tables),
+		ExcludeTables:    normalizeNames(excludeTables),
+		ExcludeTableData: normalizeNames(excludeTableData),
+	}
+}
+
+// IsEmpty indica se o backup é completo, sem nenhum filtro.
+func (f DumpFilter) IsEmpty() bool {
+	return len(f.Schemas) == 0 && len(f.ExcludeSchemas) == 0 && len(f.Tables) == 0 && len(f.ExcludeTables) == 0 && len(f.ExcludeTableData) == 0
+}
+
+// normalizeNames retorna nil para listas vazias, mantendo o JSON do filtro enxuto.
+func normalizeNames(names []string) []string {
+	normalized := normalizeTags(names)
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type RestoreStatus string
+
+var (
+	RestoreRunning   RestoreStatus = "running"
+	RestoreCompleted RestoreStatus = "completed"
+	RestoreFailed    RestoreStatus = "failed"
+)
+
+// maxRestoreOutput limita a saída do pg_restore/psql guardada em cada restauração (mantém o final).
+const maxRestoreOutput = 64 * 1024
+
+// Restore é a execução de uma restauração de backup em um datasource.
+type Restore struct {
+	ID string `json:"id"`
+	// BackupId é nulo quando o backup restaurado foi removido depois; o histórico da restauração é mantido.
+	BackupId *string `json:"backup_id"`
+	// DatasourceId é o datasource de destino; no modo new_database, o datasource em cujo servidor o banco foi criado.
+	DatasourceId string `json:"datasource_id"`
+	// Database é o banco de destino; no modo data_directory, o caminho do diretório de dados criado.
+	Database string       `json:"database"`
+	Mode     RestoreMode  `json:"mode"`
+	Scope    RestoreScope `json:"scope"`
+	// RecoveryTarget é o ponto de recuperação (PITR) de uma restauração no modo data_directory.
+	RecoveryTarget *RecoveryTarget `json:"recovery_target"`
+	Status         RestoreStatus   `json:"status"`
+	// RequestedBy identifica o solicitante de forma estável (ex: "user:<id>").
+	RequestedBy         string     `json:"requested_by"`
+	RequestedByName     string     `json:"requested_by_name"`
+	RestoreRequestId    *string    `json:"restore_request_id"`
+	PreRestoreBackupId  *string    `json:"pre_restore_backup_id"`
+	TemporaryDatabaseId *string    `json:"temporary_database_id"`
+	Error               *string    `json:"error"`
+	Output              *string    `json:"output"`
+	StartedAt           time.Time  `json:"started_at"`
+	FinishedAt          *time.Time `json:"finished_at"`
+}
+
+func NewRestore(backupId string, ds Datasource, mode RestoreMode, scope RestoreScope, requestedBy, requestedByName string) *Restore {
+	return &Restore{
+		ID:              uuid.New().String(),
+		BackupId:        &backupId,
+		DatasourceId:    ds.ID,
+		Database:        ds.Database,
+		Mode:            mode,
+		Scope:           scope,
+		Status:          RestoreRunning,
+		RequestedBy:     requestedBy,
+		RequestedByName: requestedByName,
+		StartedAt:       time.Now(),
+	}
+}
+
+func (r *Restore) SetCompleted(output string) {
+	r.Status = RestoreCompleted
+	r.Error = nil
+	r.SetOutput(output)
+	r.setFinishedAt()
+}
+
+func (r *Restore) SetFailed(err error, output string) {
+	message := err.Error()
+	r.Status = RestoreFailed
+	r.Error = &message
+	r.SetOutput(output)
+	r.setFinishedAt()
+}
+
+// SetOutput guarda a saída do comando de restauração, mantendo apenas o final quando muito extensa.
+func (r *Restore) SetOutput(output string) {
+	if output == "" {
+		return
+	}
+	if len(output) > maxRestoreOutput {
+		start := len(output) - maxRestoreOutput
+		for start < len(output) && !utf8.RuneStart(output[start]) {
+			start++
+		}
+		output = output[start:]
+	}
+	r.Output = &output
+}
+
+// Duration retorna o tempo de execução da restauração, ou zero se ainda em andamento.
+func (r *Restore) Duration() time.Duration {
+	if r.FinishedAt == nil {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+func (r *Restore) setFinishedAt() {
+	now := time.Now()
+	r.FinishedAt = &now
+}
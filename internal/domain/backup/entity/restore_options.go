@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type RestoreMode string
+
+var (
+	// RestoreOverwrite limpa o banco de destino e restaura sobre ele.
+	RestoreOverwrite RestoreMode = "overwrite"
+	// RestoreNewDatabase cria um novo banco no servidor do datasource e restaura nele.
+	RestoreNewDatabase RestoreMode = "new_database"
+	// RestoreDataDirectory extrai um backup físico em um novo diretório de dados, pronto para ser iniciado com pg_ctl.
+	RestoreDataDirectory RestoreMode = "data_directory"
+)
+
+// DefaultDatabaseNameTemplate é o modelo de nome dos bancos criados no modo new_database.
+const DefaultDatabaseNameTemplate = "{db}_restore_{timestamp}"
+
+// DefaultDataDirectoryNameTemplate é o modelo de nome dos diretórios criados no modo data_directory.
+const DefaultDataDirectoryNameTemplate = "{db}_data_{timestamp}"
+
+// maxIdentifierLength é o limite de caracteres de identificadores no PostgreSQL.
+const maxIdentifierLength = 63
+
+var ErrInvalidRestoreOptions = errors.New("opções de restauração inválidas")
+
+// ErrSelectiveRestoreUnsupported indica uma restauração seletiva de um backup no formato plain.
+var ErrSelectiveRestoreUnsupported = fmt.Errorf("%w: filtros de schemas/tabelas, data_only e schema_only exigem backup no formato custom, tar ou directory", ErrInvalidRestoreOptions)
+
+var invalidDatabaseNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+var invalidDataDirectoryChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+var validLSN = regexp.MustCompile(`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`)
+
+// RestoreOptions define como a restauração será executada.
+type RestoreOptions struct {
+	Mode RestoreMode `json:"mode"`
+	// TargetDatabase é o nome do banco a ser criado no modo new_database.
+	TargetDatabase string `json:"target_database,omitempty"`
+	// RegisterDatasource cadastra o novo banco como datasource temporário.
+	RegisterDatasource bool `json:"register_datasource,omitempty"`
+	// TTL é o tempo até o novo banco (e o datasource temporário) ser removido automaticamente.
+	TTL time.Duration `json:"ttl,omitempty"`
+	// DataDirectory é o nome do diretório de dados criado no modo data_directory.
+	DataDirectory string `json:"data_directory,omitempty"`
+	// RecoveryTarget configura o diretório de dados para recuperar até um ponto no tempo (PITR) com os WAL arquivados.
+	RecoveryTarget *RecoveryTarget `json:"recovery_target,omitempty"`
+	// Scope restringe o que é restaurado do backup.
+	Scope RestoreScope `json:"scope"`
+}
+
+func (o RestoreOptions) IsNewDatabase() bool {
+	return o.Mode == RestoreNewDatabase
+}
+
+func (o RestoreOptions) IsDataDirectory() bool {
+	return o.Mode == RestoreDataDirectory
+}
+
+func (o RestoreOptions) Validate() error {
+	if err := o.Scope.Validate(); err != nil {
+		return err
+	}
+	if o.RecoveryTarget != nil {
+		if o.Mode != RestoreDataDirectory {
+			return fmt.Errorf("%w: o ponto de recuperação só se aplica ao modo %s", ErrInvalidRestoreOptions, RestoreDataDirectory)
+		}
+		if err := o.RecoveryTarget.Validate(); err != nil {
+			return err
+		}
+	}
+	switch o.Mode {
+	case RestoreOverwrite:
+		if o.TargetDatabase != "" || o.RegisterDatasource {
+			return fmt.Errorf("%w: database_name e register_datasource só se aplicam ao modo %s", ErrInvalidRestoreOptions, RestoreNewDatabase)
+		}
+		if o.DataDirectory != "" {
+			return fmt.Errorf("%w: data_directory só se aplica ao modo %s", ErrInvalidRestoreOptions, RestoreDataDirectory)
+		}
+	case RestoreNewDatabase:
+		if o.DataDirectory != "" {
+			return fmt.Errorf("%w: data_directory só se aplica ao modo %s", ErrInvalidRestoreOptions, RestoreDataDirectory)
+		}
+		if o.TargetDatabase == "" || len(o.TargetDatabase) > maxIdentifierLength || invalidDatabaseNameChars.MatchString(o.TargetDatabase) {
+			return fmt.Errorf("%w: nome de banco inválido %q (use apenas letras minúsculas, números e \"_\", até %d caracteres)", ErrInvalidRestoreOptions, o.TargetDatabase, maxIdentifierLength)
+		}
+		if o.TTL <= 0 {
+			return fmt.Errorf("%w: o ttl do banco temporário deve ser positivo", ErrInvalidRestoreOptions)
+		}
+	case RestoreDataDirectory:
+		if o.TargetDatabase != "" || o.RegisterDatasource || o.TTL != 0 {
+			return fmt.Errorf("%w: database_name, register_datasource e ttl não se aplicam ao modo %s", ErrInvalidRestoreOptions, RestoreDataDirectory)
+		}
+		if !o.Scope.IsEmpty() {
+			return fmt.Errorf("%w: o backup físico é restaurado por completo, sem filtros nem skip_clear", ErrInvalidRestoreOptions)
+		}
+		if o.DataDirectory == "" || len(o.DataDirectory) > maxIdentifierLength || invalidDataDirectoryChars.MatchString(o.DataDirectory) {
+			return fmt.Errorf("%w: nome de diretório inválido %q (use apenas letras, números, \"_\" e \"-\", até %d caracteres)", ErrInvalidRestoreOptions, o.DataDirectory, maxIdentifierLength)
+		}
+	default:
+		return fmt.Errorf("%w: modo de restauração inválido %q", ErrInvalidRestoreOptions, o.Mode)
+	}
+	return nil
+}
+
+// RecoveryTarget é o ponto até onde os WAL arquivados são aplicados sobre um backup físico: um instante
+// ou um LSN, exclusivamente.
+type RecoveryTarget struct {
+	Time *time.Time `json:"time,omitempty"`
+	LSN  string     `json:"lsn,omitempty"`
+}
+
+func (t RecoveryTarget) Validate() error {
+	if (t.Time == nil) == (t.LSN == "") {
+		return fmt.Errorf("%w: informe recovery_target_time ou recovery_target_lsn", ErrInvalidRestoreOptions)
+	}
+	if t.LSN != "" && !validLSN.MatchString(t.LSN) {
+		return fmt.Errorf("%w: LSN inválido %q (use o formato 0/16B3748)", ErrInvalidRestoreOptions, t.LSN)
+	}
+	return nil
+}
+
+// NewDatabaseName monta o nome do banco a partir do modelo, substituindo {db} e {timestamp}.
+// O resultado contém apenas letras minúsculas, números e "_" e respeita o limite de 63 caracteres.
+func NewDatabaseName(template, database string, at time.Time) string {
+	if template == "" {
+		template = DefaultDatabaseNameTemplate
+	}
+	name := strings.NewReplacer(
+		"{db}", database,
+		"{timestamp}", at.Format("20060102150405"),
+	).Replace(template)
+	name = invalidDatabaseNameChars.ReplaceAllString(strings.ToLower(name), "_")
+	if len(name) > maxIdentifierLength {
+		name = name[len(name)-maxIdentifierLength:]
+	}
+	return strings.Trim(name, "_")
+}
+
+// RestoreScope restringe o que é restaurado de um backup. Os filtros de schemas e tabelas e os modos
+// data_only/schema_only exigem backups no formato custom (pg_restore).
+type RestoreScope struct {
+	Schemas        []string `json:"schemas,omitempty"`
+	ExcludeSchemas []string `json:"exclude_schemas,omitempty"`
+	// Tables e ExcludeTables aceitam "schema.tabela" ou apenas "tabela" (em qualquer schema).
+	Tables        []string `json:"tables,omitempty"`
+	ExcludeTables []string `json:"exclude_tables,omitempty"`
+	DataOnly      bool     `json:"data_only,omitempty"`
+	SchemaOnly    bool     `json:"schema_only,omitempty"`
+	// SkipClear não executa ClearDatabase antes da restauração.
+	SkipClear bool `json:"skip_clear,omitempty"`
+	// SkipGlobals não restaura os objetos globais (roles e tablespaces) gravados junto do backup.
+	SkipGlobals bool `json:"skip_globals,omitempty"`
+	// KeepOwners mantém os donos dos objetos na restauração (sem --no-owner). É definido pelo runner depois
+	// de restaurar os objetos globais, quando os roles donos já existem no servidor de destino.
+	KeepOwners bool `json:"-"`
+}
+
+// IsSelective indica se a restauração depende do pg_restore para filtrar o conteúdo do backup.
+func (s RestoreScope) IsSelective() bool {
+	return len(s.Schemas) > 0 || len(s.ExcludeSchemas) > 0 || len(s.Tables) > 0 || len(s.ExcludeTables) > 0 || s.DataOnly || s.SchemaOnly
+}
+
+// IsEmpty indica se a restauração é completa, sem nenhuma restrição.
+func (s RestoreScope) IsEmpty() bool {
+	return !s.IsSelective() && !s.SkipClear
+}
+
+func (s RestoreScope) Validate() error {
+	if s.DataOnly && s.SchemaOnly {
+		return fmt.Errorf("%w: data_only e schema_only não podem ser usados juntos", ErrInvalidRestoreOptions)
+	}
+	for _, names := range [][]string{s.Schemas, s.ExcludeSchemas, s.Tables, s.ExcludeTables} {
+		for _, name := range names {
+			if strings.TrimSpace(name) == "" {
+				return fmt.Errorf("%w: nomes de schemas e tabelas não podem ser vazios", ErrInvalidRestoreOptions)
+			}
+		}
+	}
+	for _, name := range append(append([]string{}, s.Tables...), s.ExcludeTables...) {
+		if strings.Count(name, ".") > 1 {
+			return fmt.Errorf("%w: tabela inválida %q (use \"schema.tabela\" ou \"tabela\")", ErrInvalidRestoreOptions, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RestoreRequestStatus string
+
+var (
+	RestoreRequestPending  RestoreRequestStatus = "pending"
+	RestoreRequestApproved RestoreRequestStatus = "approved"
+	RestoreRequestRejected RestoreRequestStatus = "rejected"
+	RestoreRequestExpired  RestoreRequestStatus = "expired"
+)
+
+var (
+	ErrRestoreRequestNotPending = errors.New("a solicitação de restauração não está pendente")
+	ErrRestoreRequestExpired    = errors.New("a solicitação de restauração expirou")
+	ErrRestoreRequestSelfReview = errors.New("a solicitação deve ser aprovada por outro usuário")
+	ErrRestoreRequestNoUser     = errors.New("solicitações e decisões de restauração exigem um usuário cadastrado: chaves de API sem usuário vinculado não são aceitas")
+)
+
+// RestoreRequest é uma solicitação de restauração em um datasource protegido,
+// que só é executada após a aprovação de um segundo usuário. BackupId é nulo quando o backup foi removido.
+type RestoreRequest struct {
+	ID              string               `json:"id"`
+	BackupId        *string              `json:"backup_id"`
+	DatasourceId    string               `json:"datasource_id"`
+	Status          RestoreRequestStatus `json:"status"`
+	Reason          string               `json:"reason"`
+	Scope           RestoreScope         `json:"scope"`
+	RequestedBy     string               `json:"requested_by"`
+	RequestedByName string               `json:"requested_by_name"`
+	DecidedBy       *string              `json:"decided_by"`
+	DecidedByName   *string              `json:"decided_by_name"`
+	DecisionComment *string              `json:"decision_comment"`
+	DecidedAt       *time.Time           `json:"decided_at"`
+	ExpiresAt       *time.Time           `json:"expires_at"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
+
+// NewRestoreRequest cria uma solicitação pendente.
+//
+// requestedBy identifica o solicitante de forma estável (ex: "user:<id>") e é comparado com o aprovador.
+// Com ttl zero a solicitação não expira.
+func NewRestoreRequest(backupId, datasourceId, reason, requestedBy, requestedByName string, ttl time.Duration) *RestoreRequest {
+	now := time.Now()
+	request := &RestoreRequest{
+		ID:              uuid.New().String(),
+		BackupId:        &backupId,
+		DatasourceId:    datasourceId,
+		Status:          RestoreRequestPending,
+		Reason:          reason,
+		RequestedBy:     requestedBy,
+		RequestedByName: requestedByName,
+		CreatedAt:       now,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		request.ExpiresAt = &expiresAt
+	}
+	return request
+}
+
+// IsExpired indica se a solicitação pendente passou do prazo de aprovação.
+func (r *RestoreRequest) IsExpired(now time.Time) bool {
+	return r.Status == RestoreRequestPending && r.ExpiresAt != nil && now.After(*r.ExpiresAt)
+}
+
+// Approve aprova a solicitação. O aprovador deve ser diferente do solicitante.
+// Caso a solicitação tenha expirado, ela é marcada como expirada e ErrRestoreRequestExpired é retornado.
+func (r *RestoreRequest) Approve(approvedBy, approvedByName, comment string) error {
+	if err := r.decide(approvedBy); err != nil {
+		return err
+	}
+	r.setDecision(RestoreRequestApproved, approvedBy, approvedByName, comment)
+	return nil
+}
+
+// Reject rejeita a solicitação. O próprio solicitante pode rejeitá-la para cancelá-la.
+func (r *RestoreRequest) Reject(rejectedBy, rejectedByName, comment string) error {
+	if r.Status != RestoreRequestPending {
+		return ErrRestoreRequestNotPending
+	}
+	r.setDecision(RestoreRequestRejected, rejectedBy, rejectedByName, comment)
+	return nil
+}
+
+func (r *RestoreRequest) decide(decidedBy string) error {
+	if r.IsExpired(time.Now()) {
+		r.Status = RestoreRequestExpired
+		return ErrRestoreRequestExpired
+	}
+	if r.Status != RestoreRequestPending {
+		return ErrRestoreRequestNotPending
+	}
+	if decidedBy == r.RequestedBy {
+		return ErrRestoreRequestSelfReview
+	}
+	return nil
+}
+
+func (r *RestoreRequest) setDecision(status RestoreRequestStatus, decidedBy, decidedByName, comment string) {
+	now := time.Now()
+	r.Status = status
+	r.DecidedBy = &decidedBy
+	r.DecidedByName = &decidedByName
+	r.DecidedAt = &now
+	if comment != "" {
+		r.DecisionComment = &comment
+	}
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidSchedule indica um agendamento inválido.
+var ErrInvalidSchedule = errors.New("agendamento inválido")
+
+// Schedule é um agendamento adicional de um datasource, com formato e retenção próprios, executado
+// independentemente do cron do datasource (ex: estrutura a cada hora e dump completo à noite).
+type Schedule struct {
+	ID           string `json:"id"`
+	DatasourceId string `json:"datasource_id"`
+	Name         string `json:"name"`
+	CronExpr     string `json:"cron_expr"`
+	// TimeZone é o fuso horário IANA em que CronExpr é interpretada (ex: America/Sao_Paulo); vazio usa o do servidor.
+	TimeZone string `json:"time_zone"`
+	Enabled  bool   `json:"enabled"`
+	BackupOptions
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSchedule(datasourceId, name, cronExpr, timeZone string, enabled bool, options BackupOptions) (*Schedule, error) {
+	schedule := &Schedule{
+		ID:            uuid.New().String(),
+		DatasourceId:  datasourceId,
+		Name:          name,
+		CronExpr:      cronExpr,
+		TimeZone:      timeZone,
+		Enabled:       enabled,
+		BackupOptions: options,
+		CreatedAt:     time.Now(),
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *Schedule) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("%w: o nome é obrigatório", ErrInvalidSchedule)
+	}
+	if strings.TrimSpace(s.CronExpr) == "" {
+		return fmt.Errorf("%w: a expressão cron é obrigatória", ErrInvalidSchedule)
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+	}
+	return s.BackupOptions.Validate()
+}
+
+// Apply retorna o datasource com o cron, o fuso horário e as opções do agendamento. As opções substituem as do
+// datasource e as do seu perfil de backup.
+func (s Schedule) Apply(ds Datasource) Datasource {
+	ds = s.BackupOptions.Apply(ds)
+	ds.Cron = &CronExpr{CronExpr: s.CronExpr, Description: s.Name, Enabled: s.Enabled, TimeZone: s.TimeZone}
+	ds.ScheduleId = &s.ID
+	return ds
+}
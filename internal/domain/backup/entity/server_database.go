@@ -0,0 +1,12 @@
+package entity
+
+// ServerDatabase é um banco de dados encontrado na descoberta de um servidor.
+type ServerDatabase struct {
+	Name string `json:"name"`
+	// SizeBytes é nulo quando o usuário não tem permissão de CONNECT no banco.
+	SizeBytes *int64 `json:"size_bytes"`
+	// Registered indica que o banco já tem um datasource no mesmo host e porta.
+	Registered bool `json:"registered"`
+	// DatasourceId é o datasource já cadastrado, omitido quando está fora do escopo do usuário.
+	DatasourceId *string `json:"datasource_id"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemporaryDatabase é um banco criado por uma restauração no modo new_database,
+// removido automaticamente quando expira.
+type TemporaryDatabase struct {
+	ID           string `json:"id"`
+	DatasourceId string `json:"datasource_id"`
+	BackupId     string `json:"backup_id"`
+	Database     string `json:"database"`
+	// RegisteredDatasourceId é o datasource temporário cadastrado para o banco, se solicitado.
+	RegisteredDatasourceId *string    `json:"registered_datasource_id"`
+	ExpiresAt              time.Time  `json:"expires_at"`
+	CreatedAt              time.Time  `json:"created_at"`
+	DroppedAt              *time.Time `json:"dropped_at"`
+	Error                  *string    `json:"error"`
+}
+
+func NewTemporaryDatabase(datasourceId, backupId, database string, ttl time.Duration) *TemporaryDatabase {
+	now := time.Now()
+	return &TemporaryDatabase{
+		ID:           uuid.New().String(),
+		DatasourceId: datasourceId,
+		BackupId:     backupId,
+		Database:     database,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}
+}
+
+func (t *TemporaryDatabase) SetDropped() {
+	now := time.Now()
+	t.DroppedAt = &now
+	t.Error = nil
+}
+
+func (t *TemporaryDatabase) SetError(err error) {
+	message := err.Error()
+	t.Error = &message
+}
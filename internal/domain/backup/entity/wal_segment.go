@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWalFileName indica um arquivo recebido do archive_command com nome que não é de WAL.
+var ErrInvalidWalFileName = errors.New("nome de arquivo de WAL inválido")
+
+// ErrWalSegmentConflict indica que o arquivo já foi arquivado com outro conteúdo.
+var ErrWalSegmentConflict = errors.New("o arquivo de WAL já foi arquivado com outro conteúdo")
+
+// walFileName aceita segmentos (000000010000000000000001), segmentos parciais (.partial), históricos de
+// backup (.00000028.backup) e históricos de timeline (00000002.history).
+var walFileName = regexp.MustCompile(`^([0-9A-F]{24}(\.partial|\.[0-9A-F]{8}\.backup)?|[0-9A-F]{8}\.history)$`)
+
+// WalSegment é um arquivo de WAL enviado pelo archive_command do servidor de um datasource, guardado
+// compactado com gzip para a recuperação até um ponto no tempo (PITR) a partir de um backup físico.
+type WalSegment struct {
+	ID           string `json:"id"`
+	DatasourceId string `json:"datasource_id"`
+	FileName     string `json:"file_name"`
+	FilePath     string `json:"file_path"`
+	// FileSize é o tamanho do arquivo compactado.
+	FileSize int64 `json:"file_size"`
+	// Checksum é o SHA-256 (hex) do conteúdo original, usado para aceitar reenvios idênticos.
+	Checksum   string    `json:"checksum"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+func NewWalSegment(datasourceId, fileName string) *WalSegment {
+	return &WalSegment{
+		ID:           uuid.New().String(),
+		DatasourceId: datasourceId,
+		FileName:     fileName,
+		ArchivedAt:   time.Now(),
+	}
+}
+
+// ValidWalFileName indica se o nome é de um arquivo que o PostgreSQL envia ao archive_command.
+func ValidWalFileName(name string) bool {
+	return walFileName.MatchString(name)
+}
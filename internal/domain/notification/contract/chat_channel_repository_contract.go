@@ -0,0 +1,11 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+type IChatChannelRepository interface {
+	GetChannels(datasourceId *string) ([]entity.ChatChannel, error)
+	GetChannel(entityID string) (entity.ChatChannel, error)
+	CreateChannel(entity entity.ChatChannel) error
+	UpdateChannel(entity entity.ChatChannel) error
+	DeleteChannel(entityID string) error
+}
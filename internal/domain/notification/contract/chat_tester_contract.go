@@ -0,0 +1,8 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+// IChatTester envia uma mensagem de teste para validar a configuração de um canal de chat.
+type IChatTester interface {
+	SendTest(channel entity.ChatChannel) error
+}
@@ -0,0 +1,6 @@
+package contract
+
+// IDigestSender envia o resumo periódico com o último backup de cada datasource.
+type IDigestSender interface {
+	SendDigest() error
+}
@@ -0,0 +1,11 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+type IEmailRecipientRepository interface {
+	GetRecipients(datasourceId *string) ([]entity.EmailRecipient, error)
+	GetRecipient(entityID string) (entity.EmailRecipient, error)
+	CreateRecipient(entity entity.EmailRecipient) error
+	UpdateRecipient(entity entity.EmailRecipient) error
+	DeleteRecipient(entityID string) error
+}
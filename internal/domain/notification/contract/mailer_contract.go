@@ -0,0 +1,6 @@
+package contract
+
+// IMailer envia mensagens de e-mail em texto simples.
+type IMailer interface {
+	Send(to []string, subject, body string) error
+}
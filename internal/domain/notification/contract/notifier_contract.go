@@ -0,0 +1,9 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+// INotifier publica eventos do ciclo de vida de backups e restaurações para canais externos.
+// Implementações devem ser não bloqueantes: a entrega acontece em segundo plano.
+type INotifier interface {
+	Notify(event entity.Event)
+}
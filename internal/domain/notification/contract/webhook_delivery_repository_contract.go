@@ -0,0 +1,9 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+type IWebhookDeliveryRepository interface {
+	GetDeliveries(webhookId string) ([]entity.WebhookDelivery, error)
+	CreateDelivery(entity entity.WebhookDelivery) error
+	UpdateDelivery(entity entity.WebhookDelivery) error
+}
@@ -0,0 +1,11 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+type IWebhookRepository interface {
+	GetWebhooks(datasourceId *string) ([]entity.Webhook, error)
+	GetWebhook(entityID string) (entity.Webhook, error)
+	CreateWebhook(entity entity.Webhook) error
+	UpdateWebhook(entity entity.Webhook) error
+	DeleteWebhook(entityID string) error
+}
@@ -0,0 +1,10 @@
+package contract
+
+import "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+
+// IWebhookSender entrega eventos de forma síncrona para um webhook específico.
+type IWebhookSender interface {
+	// Deliver envia o evento ao webhook, realizando até maxAttempts tentativas,
+	// e retorna o registro da entrega com o status final.
+	Deliver(webhook entity.Webhook, event entity.Event, maxAttempts int) (entity.WebhookDelivery, error)
+}
@@ -0,0 +1,13 @@
+package dto
+
+type CreateChatChannelDto struct {
+	DatasourceId *string `json:"datasource_id"`
+	Kind         string  `json:"kind"`
+	WebhookURL   string  `json:"webhook_url"`
+	Severity     string  `json:"severity"`
+	Enabled      bool    `json:"enabled"`
+}
+
+type UpdateChatChannelDto struct {
+	CreateChatChannelDto
+}
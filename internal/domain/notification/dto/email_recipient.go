@@ -0,0 +1,12 @@
+package dto
+
+type CreateEmailRecipientDto struct {
+	DatasourceId *string `json:"datasource_id"`
+	Email        string  `json:"email"`
+	Alerts       bool    `json:"alerts"`
+	Digest       bool    `json:"digest"`
+}
+
+type UpdateEmailRecipientDto struct {
+	CreateEmailRecipientDto
+}
@@ -0,0 +1,13 @@
+package dto
+
+type CreateWebhookDto struct {
+	DatasourceId *string  `json:"datasource_id"`
+	URL          string   `json:"url"`
+	Secret       string   `json:"secret"`
+	Events       []string `json:"events"`
+	Enabled      bool     `json:"enabled"`
+}
+
+type UpdateWebhookDto struct {
+	CreateWebhookDto
+}
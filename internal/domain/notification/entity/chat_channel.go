@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/encryption"
+	"github.com/google/uuid"
+)
+
+type ChatKind string
+type ChatSeverity string
+
+var (
+	ChatSlack ChatKind = "slack"
+	ChatTeams ChatKind = "teams"
+
+	SeverityFailures ChatSeverity = "failures"
+	SeverityAll      ChatSeverity = "all"
+)
+
+type ChatChannel struct {
+	ID           string       `json:"id"`
+	DatasourceId *string      `json:"datasource_id"`
+	Kind         ChatKind     `json:"kind"`
+	WebhookURL   string       `json:"-"`
+	Severity     ChatSeverity `json:"severity"`
+	Enabled      bool         `json:"enabled"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// NewChatChannel cria um canal de chat (webhook de entrada do Slack ou Teams).
+// Quando datasourceId é nil o canal é global e recebe eventos de todos os datasources.
+func NewChatChannel(datasourceId *string, kind ChatKind, webhookURL string, severity ChatSeverity, enabled bool) (*ChatChannel, error) {
+	channel := &ChatChannel{
+		ID:           uuid.New().String(),
+		DatasourceId: datasourceId,
+		Kind:         kind,
+		WebhookURL:   webhookURL,
+		Severity:     severity,
+		Enabled:      enabled,
+		CreatedAt:    time.Now(),
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (c *ChatChannel) Validate() error {
+	if c.Kind != ChatSlack && c.Kind != ChatTeams {
+		return fmt.Errorf("tipo de canal inválido: %s", c.Kind)
+	}
+	if c.Severity != SeverityFailures && c.Severity != SeverityAll {
+		return fmt.Errorf("severidade inválida: %s", c.Severity)
+	}
+	webhookURL := c.WebhookURL
+	if c.IsEncoded() {
+		decoded, err := c.Decode()
+		if err != nil {
+			return err
+		}
+		webhookURL = decoded.WebhookURL
+	}
+	parsed, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("url do webhook inválida")
+	}
+	return nil
+}
+
+// Accepts indica se o canal deve receber o evento informado, considerando datasource e severidade.
+func (c *ChatChannel) Accepts(event Event) bool {
+	if !c.Enabled {
+		return false
+	}
+	if c.DatasourceId != nil && *c.DatasourceId != event.DatasourceId {
+		return false
+	}
+	if event.Type == EventTest {
+		return true
+	}
+	return c.Severity == SeverityAll || event.NeedsAttention()
+}
+
+func (c *ChatChannel) Encode() (ChatChannel, error) {
+	channel := *c
+	if c.IsEncoded() {
+		return channel, nil
+	}
+	encodedURL, err := encryption.Encrypt(c.WebhookURL)
+	if err != nil {
+		return ChatChannel{}, err
+	}
+	channel.WebhookURL = encodedURL
+	return channel, nil
+}
+
+func (c *ChatChannel) Decode() (ChatChannel, error) {
+	channel := *c
+	if !c.IsEncoded() {
+		return channel, nil
+	}
+	decodedURL, err := encryption.Decrypt(c.WebhookURL)
+	if err != nil {
+		return ChatChannel{}, err
+	}
+	channel.WebhookURL = decodedURL
+	return channel, nil
+}
+
+func (c *ChatChannel) IsEncoded() bool {
+	_, err := encryption.Decrypt(c.WebhookURL)
+	return err == nil
+}
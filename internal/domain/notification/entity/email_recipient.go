@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailRecipient struct {
+	ID           string    `json:"id"`
+	DatasourceId *string   `json:"datasource_id"`
+	Email        string    `json:"email"`
+	Alerts       bool      `json:"alerts"`
+	Digest       bool      `json:"digest"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewEmailRecipient cria um destinatário de e-mail.
+// Quando datasourceId é nil o destinatário é global e recebe alertas e resumo de todos os datasources.
+func NewEmailRecipient(datasourceId *string, email string, alerts, digest bool) (*EmailRecipient, error) {
+	recipient := &EmailRecipient{
+		ID:           uuid.New().String(),
+		DatasourceId: datasourceId,
+		Email:        email,
+		Alerts:       alerts,
+		Digest:       digest,
+		CreatedAt:    time.Now(),
+	}
+	if err := recipient.Validate(); err != nil {
+		return nil, err
+	}
+	return recipient, nil
+}
+
+func (r *EmailRecipient) Validate() error {
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return fmt.Errorf("e-mail inválido: %s", r.Email)
+	}
+	return nil
+}
+
+// Covers indica se o destinatário acompanha o datasource informado.
+func (r *EmailRecipient) Covers(datasourceId string) bool {
+	return r.DatasourceId == nil || *r.DatasourceId == datasourceId
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"time"
+
+	backupEntity "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+var (
+	EventBackupStarted    EventType = "backup.started"
+	EventBackupCompleted  EventType = "backup.completed"
+	EventBackupFailed     EventType = "backup.failed"
+	EventBackupSkipped    EventType = "backup.skipped"
+	EventRestoreCompleted EventType = "restore.completed"
+	EventRestoreFailed    EventType = "restore.failed"
+	EventRestoreRequested EventType = "restore.requested"
+	EventRestoreApproved  EventType = "restore.approved"
+	EventRestoreRejected  EventType = "restore.rejected"
+	EventRetentionDeleted EventType = "retention.deleted"
+	EventTest             EventType = "test"
+)
+
+// AllEventTypes lista os eventos que podem ser assinados pelos canais de notificação.
+var AllEventTypes = []EventType{
+	EventBackupStarted,
+	EventBackupCompleted,
+	EventBackupFailed,
+	EventBackupSkipped,
+	EventRestoreCompleted,
+	EventRestoreFailed,
+	EventRestoreRequested,
+	EventRestoreApproved,
+	EventRestoreRejected,
+	EventRetentionDeleted,
+}
+
+func (t EventType) IsValid() bool {
+	for _, eventType := range AllEventTypes {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+type Event struct {
+	ID           string               `json:"id"`
+	Type         EventType            `json:"type"`
+	DatasourceId string               `json:"datasource_id"`
+	Database     string               `json:"database"`
+	Host         string               `json:"host"`
+	Backup       *backupEntity.Backup `json:"backup,omitempty"`
+	// RestoreRequest é preenchido nos eventos do fluxo de aprovação de restaurações.
+	RestoreRequest *backupEntity.RestoreRequest `json:"restore_request,omitempty"`
+	Error          string                       `json:"error,omitempty"`
+	OccurredAt     time.Time                    `json:"occurred_at"`
+}
+
+// NewEvent cria um evento para o datasource informado.
+// O backup é copiado para que alterações posteriores não afetem entregas ainda pendentes.
+func NewEvent(eventType EventType, ds backupEntity.Datasource, backup *backupEntity.Backup) Event {
+	if backup != nil {
+		backupCopy := *backup
+		backup = &backupCopy
+	}
+	return Event{
+		ID:           uuid.New().String(),
+		Type:         eventType,
+		DatasourceId: ds.ID,
+		Database:     ds.Database,
+		Host:         ds.Host,
+		Backup:       backup,
+		OccurredAt:   time.Now(),
+	}
+}
+
+func (e Event) WithError(err error) Event {
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// WithRestoreRequest anexa ao evento a solicitação de restauração informada.
+func (e Event) WithRestoreRequest(request backupEntity.RestoreRequest) Event {
+	e.RestoreRequest = &request
+	return e
+}
+
+func (e Event) IsFailure() bool {
+	return e.Type == EventBackupFailed || e.Type == EventRestoreFailed
+}
+
+// NeedsAttention indica se o evento exige ação imediata: falhas e restaurações aguardando aprovação.
+func (e Event) NeedsAttention() bool {
+	return e.IsFailure() || e.Type == EventRestoreRequested
+}
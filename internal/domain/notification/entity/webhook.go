@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/encryption"
+	"github.com/google/uuid"
+)
+
+type Webhook struct {
+	ID           string      `json:"id"`
+	DatasourceId *string     `json:"datasource_id"`
+	URL          string      `json:"url"`
+	Secret       string      `json:"-"`
+	Events       []EventType `json:"events"`
+	Enabled      bool        `json:"enabled"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// NewWebhook cria um webhook validando a URL e os eventos assinados.
+// Quando datasourceId é nil o webhook é global e recebe eventos de todos os datasources.
+// Se o segredo não for informado, um segredo aleatório é gerado para a assinatura HMAC.
+func NewWebhook(datasourceId *string, rawURL, secret string, events []EventType, enabled bool) (*Webhook, error) {
+	if err := validateWebhook(rawURL, events); err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		generated, err := GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+	return &Webhook{
+		ID:           uuid.New().String(),
+		DatasourceId: datasourceId,
+		URL:          rawURL,
+		Secret:       secret,
+		Events:       events,
+		Enabled:      enabled,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func (w *Webhook) Validate() error {
+	return validateWebhook(w.URL, w.Events)
+}
+
+// Subscribes indica se o webhook deve receber o evento informado.
+func (w *Webhook) Subscribes(event Event) bool {
+	if !w.Enabled {
+		return false
+	}
+	if w.DatasourceId != nil && *w.DatasourceId != event.DatasourceId {
+		return false
+	}
+	for _, eventType := range w.Events {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Webhook) Encode() (Webhook, error) {
+	webhook := *w
+	if w.IsEncoded() {
+		return webhook, nil
+	}
+	encodedSecret, err := encryption.Encrypt(w.Secret)
+	if err != nil {
+		return Webhook{}, err
+	}
+	webhook.Secret = encodedSecret
+	return webhook, nil
+}
+
+func (w *Webhook) Decode() (Webhook, error) {
+	webhook := *w
+	if !w.IsEncoded() {
+		return webhook, nil
+	}
+	decodedSecret, err := encryption.Decrypt(w.Secret)
+	if err != nil {
+		return Webhook{}, err
+	}
+	webhook.Secret = decodedSecret
+	return webhook, nil
+}
+
+func (w *Webhook) IsEncoded() bool {
+	_, err := encryption.Decrypt(w.Secret)
+	return err == nil
+}
+
+func GenerateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func validateWebhook(rawURL string, events []EventType) error {
+	parsed, err := url.ParseRequestURI(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url do webhook inválida: %s", rawURL)
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("o webhook deve assinar ao menos um evento")
+	}
+	for _, eventType := range events {
+		if !eventType.IsValid() {
+			return fmt.Errorf("evento inválido: %s", eventType)
+		}
+	}
+	return nil
+}
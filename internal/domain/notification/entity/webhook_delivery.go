@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+var (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID             string         `json:"id"`
+	WebhookId      string         `json:"webhook_id"`
+	EventId        string         `json:"event_id"`
+	EventType      EventType      `json:"event_type"`
+	Payload        string         `json:"payload"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	ResponseStatus *int           `json:"response_status"`
+	Error          *string        `json:"error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at"`
+}
+
+func NewWebhookDelivery(webhookId string, event Event, payload string) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:        uuid.New().String(),
+		WebhookId: webhookId,
+		EventId:   event.ID,
+		EventType: event.Type,
+		Payload:   payload,
+		Status:    DeliveryPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (d *WebhookDelivery) RegisterAttempt(responseStatus int, err error) {
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	if responseStatus > 0 {
+		d.ResponseStatus = &responseStatus
+	} else {
+		d.ResponseStatus = nil
+	}
+	if err != nil {
+		msg := err.Error()
+		d.Error = &msg
+	} else {
+		d.Error = nil
+	}
+}
+
+func (d *WebhookDelivery) SetSucceeded() {
+	d.Status = DeliverySucceeded
+}
+
+func (d *WebhookDelivery) SetFailed() {
+	d.Status = DeliveryFailed
+}
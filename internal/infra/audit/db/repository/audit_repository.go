@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IAuditRepository = (*AuditRepository)(nil)
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db}
+}
+
+// CreateEntry implements IAuditRepository.
+func (repo *AuditRepository) CreateEntry(entity entity.Entry) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO audit_entries (id, action, resource_type, resource_id, actor_id, actor_name, actor_kind, actor_user_id, request_id, client_ip, before, after, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		entity.ID,
+		entity.Action,
+		entity.ResourceType,
+		entity.ResourceId,
+		entity.Actor.ID,
+		entity.Actor.Name,
+		entity.Actor.Kind,
+		entity.Actor.UserId,
+		entity.RequestId,
+		entity.ClientIP,
+		nullableJSON(entity.Before),
+		nullableJSON(entity.After),
+		pq.Array(entity.Changes),
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// GetEntries implements IAuditRepository.
+func (repo *AuditRepository) GetEntries(filter dto.AuditFilter) ([]entity.Entry, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorId != "" {
+		addCondition("(actor_id = $%[1]d OR actor_user_id::text = $%[1]d)", filter.ActorId)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		addCondition("resource_type = $%d", filter.ResourceType)
+	}
+	if filter.ResourceId != "" {
+		addCondition("resource_id = $%d", filter.ResourceId)
+	}
+	if filter.RequestId != "" {
+		addCondition("request_id = $%d", filter.RequestId)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	query := `
+		SELECT id, action, resource_type, resource_id, actor_id, actor_name, actor_kind, actor_user_id, request_id, client_ip, before, after, changes, created_at
+		FROM audit_entries`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	args = append(args, limit, max(filter.Offset, 0))
+	query += fmt.Sprintf("\n\t\tORDER BY created_at DESC\n\t\tLIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]entity.Entry, 0)
+	for rows.Next() {
+		var (
+			entry         entity.Entry
+			before, after []byte
+		)
+		err := rows.Scan(
+			&entry.ID,
+			&entry.Action,
+			&entry.ResourceType,
+			&entry.ResourceId,
+			&entry.Actor.ID,
+			&entry.Actor.Name,
+			&entry.Actor.Kind,
+			&entry.Actor.UserId,
+			&entry.RequestId,
+			&entry.ClientIP,
+			&before,
+			&after,
+			pq.Array(&entry.Changes),
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before = before
+		entry.After = after
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func nullableJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/audit/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+type AuditController struct {
+	auditRepo contract.IAuditRepository
+}
+
+func NewAuditController(auditRepo contract.IAuditRepository) *AuditController {
+	return &AuditController{auditRepo}
+}
+
+// List retorna a trilha de auditoria, da mais recente para a mais antiga.
+//
+// Filtros (query string): actor, action, resource_type, resource_id, request_id,
+// from e to (RFC 3339), limit (padrão 100, máximo 1000) e offset.
+func (c *AuditController) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := dto.AuditFilter{
+		ActorId:      query.Get("actor"),
+		Action:       query.Get("action"),
+		ResourceType: query.Get("resource_type"),
+		ResourceId:   query.Get("resource_id"),
+		RequestId:    query.Get("request_id"),
+	}
+
+	for name, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			utils.JSONError(w, http.StatusBadRequest, "parametro '"+name+"' inválido, use RFC 3339")
+			return
+		}
+		*target = &parsed
+	}
+	for name, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			utils.JSONError(w, http.StatusBadRequest, "parametro '"+name+"' inválido")
+			return
+		}
+		*target = parsed
+	}
+
+	entries, err := c.auditRepo.GetEntries(filter)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar a auditoria")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, entries)
+}
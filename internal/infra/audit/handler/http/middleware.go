@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/audit"
+	"github.com/go-chi/chi/middleware"
+)
+
+// RequestInfo adiciona ao contexto o request ID e o IP do cliente usados na auditoria.
+// Deve ser registrado após middleware.RequestID e middleware.RealIP.
+func RequestInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			clientIP = host
+		}
+
+		ctx := audit.WithRequestInfo(r.Context(), audit.RequestInfo{
+			RequestId: middleware.GetReqID(r.Context()),
+			ClientIP:  clientIP,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/lib/pq"
+)
+
+type ApiKeyRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IApiKeyRepository = (*ApiKeyRepository)(nil)
+
+func NewApiKeyRepository(db *sql.DB) *ApiKeyRepository {
+	return &ApiKeyRepository{db}
+}
+
+// GetApiKeys implements IApiKeyRepository.
+func (repo *ApiKeyRepository) GetApiKeys() ([]entity.ApiKey, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, name, prefix, user_id, key_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := make([]entity.ApiKey, 0)
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+// GetApiKey implements IApiKeyRepository.
+func (repo *ApiKeyRepository) GetApiKey(entityID string) (entity.ApiKey, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, name, prefix, user_id, key_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanApiKey(row)
+}
+
+// GetApiKeyByHash implements IApiKeyRepository.
+func (repo *ApiKeyRepository) GetApiKeyByHash(keyHash string) (entity.ApiKey, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, name, prefix, user_id, key_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`, keyHash)
+	return scanApiKey(row)
+}
+
+// CreateApiKey implements IApiKeyRepository.
+func (repo *ApiKeyRepository) CreateApiKey(entity entity.ApiKey) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO api_keys (id, name, prefix, user_id, key_hash, scopes, created_at, last_used_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Prefix,
+		entity.UserId,
+		entity.KeyHash,
+		pq.Array(scopesToStrings(entity.Scopes)),
+		entity.CreatedAt,
+		entity.LastUsedAt,
+		entity.RevokedAt,
+	)
+	return err
+}
+
+// RevokeApiKey implements IApiKeyRepository.
+func (repo *ApiKeyRepository) RevokeApiKey(entityID string, revokedAt time.Time) error {
+	result, err := repo.db.Exec(`
+		UPDATE api_keys
+		SET revoked_at = $2
+		WHERE id = $1::uuid AND revoked_at IS NULL
+	`, entityID, revokedAt)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// TouchApiKey implements IApiKeyRepository.
+func (repo *ApiKeyRepository) TouchApiKey(entityID string, usedAt time.Time) error {
+	_, err := repo.db.Exec(`
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE id = $1::uuid
+	`, entityID, usedAt)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanApiKey(row rowScanner) (entity.ApiKey, error) {
+	var (
+		apiKey entity.ApiKey
+		scopes []string
+	)
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.UserId,
+		&apiKey.KeyHash,
+		pq.Array(&scopes),
+		&apiKey.CreatedAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+	)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	apiKey.Scopes = make([]entity.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		apiKey.Scopes = append(apiKey.Scopes, entity.Scope(scope))
+	}
+	return apiKey, nil
+}
+
+func scopesToStrings(scopes []entity.Scope) []string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return values
+}
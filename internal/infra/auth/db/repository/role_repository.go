@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/lib/pq"
+)
+
+type RoleRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IRoleRepository = (*RoleRepository)(nil)
+
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{db}
+}
+
+// GetRoles implements IRoleRepository.
+func (repo *RoleRepository) GetRoles() ([]entity.Role, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, name, description, permissions, created_at
+		FROM roles
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]entity.Role, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetRole implements IRoleRepository.
+func (repo *RoleRepository) GetRole(entityID string) (entity.Role, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, name, description, permissions, created_at
+		FROM roles
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanRole(row)
+}
+
+// GetRoleByName implements IRoleRepository.
+func (repo *RoleRepository) GetRoleByName(name string) (entity.Role, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, name, description, permissions, created_at
+		FROM roles
+		WHERE name = $1
+	`, name)
+	return scanRole(row)
+}
+
+// CreateRole implements IRoleRepository.
+func (repo *RoleRepository) CreateRole(entity entity.Role) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO roles (id, name, description, permissions, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Description,
+		pq.Array(permissionsToStrings(entity.Permissions)),
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateRole implements IRoleRepository.
+func (repo *RoleRepository) UpdateRole(entity entity.Role) error {
+	_, err := repo.db.Exec(`
+		UPDATE roles
+		SET name = $2, description = $3, permissions = $4
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Description,
+		pq.Array(permissionsToStrings(entity.Permissions)),
+	)
+	return err
+}
+
+// DeleteRole implements IRoleRepository.
+func (repo *RoleRepository) DeleteRole(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM roles
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanRole(row rowScanner) (entity.Role, error) {
+	var (
+		role        entity.Role
+		permissions []string
+	)
+	err := row.Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		pq.Array(&permissions),
+		&role.CreatedAt,
+	)
+	if err != nil {
+		return entity.Role{}, err
+	}
+	role.Permissions = stringsToPermissions(permissions)
+	return role, nil
+}
+
+func permissionsToStrings(permissions []entity.Permission) []string {
+	values := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		values = append(values, string(permission))
+	}
+	return values
+}
+
+func stringsToPermissions(values []string) []entity.Permission {
+	permissions := make([]entity.Permission, 0, len(values))
+	for _, value := range values {
+		permissions = append(permissions, entity.Permission(value))
+	}
+	return permissions
+}
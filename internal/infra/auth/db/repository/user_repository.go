@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/lib/pq"
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IUserRepository = (*UserRepository)(nil)
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db}
+}
+
+// GetUsers implements IUserRepository.
+func (repo *UserRepository) GetUsers() ([]entity.User, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, name, email, enabled, created_at
+		FROM users
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0)
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Enabled, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetUser implements IUserRepository.
+func (repo *UserRepository) GetUser(entityID string) (entity.User, error) {
+	var user entity.User
+
+	row := repo.db.QueryRow(`
+		SELECT id, name, email, enabled, created_at
+		FROM users
+		WHERE id = $1::uuid
+	`, entityID)
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Enabled, &user.CreatedAt); err != nil {
+		return entity.User{}, err
+	}
+	return user, nil
+}
+
+// GetUserByEmail implements IUserRepository.
+func (repo *UserRepository) GetUserByEmail(email string) (entity.User, error) {
+	var user entity.User
+
+	row := repo.db.QueryRow(`
+		SELECT id, name, email, enabled, created_at
+		FROM users
+		WHERE lower(email) = lower($1)
+	`, email)
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Enabled, &user.CreatedAt); err != nil {
+		return entity.User{}, err
+	}
+	return user, nil
+}
+
+// CreateUser implements IUserRepository.
+func (repo *UserRepository) CreateUser(entity entity.User) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO users (id, name, email, enabled, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Email,
+		entity.Enabled,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateUser implements IUserRepository.
+func (repo *UserRepository) UpdateUser(entity entity.User) error {
+	_, err := repo.db.Exec(`
+		UPDATE users
+		SET name = $2, email = $3, enabled = $4
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Email,
+		entity.Enabled,
+	)
+	return err
+}
+
+// DeleteUser implements IUserRepository.
+func (repo *UserRepository) DeleteUser(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM users
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetGrants implements IUserRepository.
+//
+// As permissões de cada concessão são carregadas a partir da role atribuída.
+func (repo *UserRepository) GetGrants(userId string) ([]entity.Grant, error) {
+	rows, err := repo.db.Query(`
+		SELECT g.id, g.user_id, g.role_id, r.name, r.permissions, g.datasource_id, g.tag, g.created_at
+		FROM user_grants g
+		INNER JOIN roles r ON r.id = g.role_id
+		WHERE g.user_id = $1::uuid
+		ORDER BY g.created_at
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]entity.Grant, 0)
+	for rows.Next() {
+		var (
+			grant       entity.Grant
+			permissions []string
+		)
+		err := rows.Scan(
+			&grant.ID,
+			&grant.UserId,
+			&grant.RoleId,
+			&grant.RoleName,
+			pq.Array(&permissions),
+			&grant.DatasourceId,
+			&grant.Tag,
+			&grant.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		grant.Permissions = stringsToPermissions(permissions)
+		grants = append(grants, grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// CreateGrant implements IUserRepository.
+func (repo *UserRepository) CreateGrant(entity entity.Grant) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO user_grants (id, user_id, role_id, datasource_id, tag, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		entity.ID,
+		entity.UserId,
+		entity.RoleId,
+		entity.DatasourceId,
+		entity.Tag,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// DeleteGrant implements IUserRepository.
+func (repo *UserRepository) DeleteGrant(userId, grantId string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM user_grants
+		WHERE id = $1::uuid AND user_id = $2::uuid
+	`, grantId, userId)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
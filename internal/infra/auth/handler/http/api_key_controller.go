@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type ApiKeyController struct {
+	apiKeyRepo    contract.IApiKeyRepository
+	auditRecorder auditContract.IRecorder
+}
+
+func NewApiKeyController(apiKeyRepo contract.IApiKeyRepository, auditRecorder auditContract.IRecorder) *ApiKeyController {
+	return &ApiKeyController{apiKeyRepo, auditRecorder}
+}
+
+func (c *ApiKeyController) List(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := c.apiKeyRepo.GetApiKeys()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as chaves de API")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, apiKeys)
+}
+
+func (c *ApiKeyController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateApiKeyDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	scopes := make([]entity.Scope, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		scopes = append(scopes, entity.Scope(scope))
+	}
+
+	apiKey, plainKey, err := entity.NewApiKey(input.Name, input.UserId, scopes)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.apiKeyRepo.CreateApiKey(*apiKey); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar a chave de API")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionApiKeyCreate, "api_key", apiKey.ID, nil, apiKey)
+
+	// A chave em texto puro só é exibida na criação; apenas o hash é armazenado.
+	response := map[string]any{
+		"id":      apiKey.ID,
+		"name":    apiKey.Name,
+		"user_id": apiKey.UserId,
+		"scopes":  apiKey.Scopes,
+		"key":     plainKey,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *ApiKeyController) Revoke(w http.ResponseWriter, r *http.Request) {
+	apiKeyId := chi.URLParam(r, "id")
+	before, _ := c.apiKeyRepo.GetApiKey(apiKeyId)
+	if err := c.apiKeyRepo.RevokeApiKey(apiKeyId, time.Now()); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a chave de API não existe ou já foi revogada")
+		return
+	}
+	after, _ := c.apiKeyRepo.GetApiKey(apiKeyId)
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionApiKeyRevoke, "api_key", apiKeyId, before, after)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+type MeController struct{}
+
+func NewMeController() *MeController {
+	return &MeController{}
+}
+
+type meResponse struct {
+	entity.Principal
+	// Permissions lista as permissões exercíveis em ao menos um datasource;
+	// as concessões detalham em quais datasources ou tags cada uma se aplica.
+	Permissions []entity.Permission `json:"permissions"`
+}
+
+// Get retorna o principal autenticado e o que ele pode fazer, para uso do frontend.
+func (c *MeController) Get(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.JSONError(w, http.StatusUnauthorized, "não autenticado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, meResponse{
+		Principal:   principal,
+		Permissions: principal.Permissions(),
+	})
+}
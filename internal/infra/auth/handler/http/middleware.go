@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+const ApiKeyHeader = "X-API-Key"
+
+// Authenticate exige uma credencial válida em "Authorization: Bearer <chave>" ou no cabeçalho X-API-Key,
+// adicionando o principal autenticado ao contexto da requisição.
+func Authenticate(authenticator contract.IAuthenticator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			credential := credentialFromRequest(r)
+			if credential == "" {
+				utils.JSONError(w, http.StatusUnauthorized, "não autenticado")
+				return
+			}
+
+			principal, err := authenticator.Authenticate(credential)
+			if err != nil {
+				utils.JSONError(w, http.StatusUnauthorized, "credencial inválida")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func credentialFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if token, found := strings.CutPrefix(header, "Bearer "); found {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get(ApiKeyHeader))
+}
+
+// RequirePermission exige que o principal autenticado possa exercer a permissão em ao menos um datasource.
+// A verificação por datasource é feita nos próprios handlers.
+func RequirePermission(permission entity.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				utils.JSONError(w, http.StatusUnauthorized, "não autenticado")
+				return
+			}
+			if !principal.CanAny(permission) {
+				utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type RoleController struct {
+	roleRepo      contract.IRoleRepository
+	auditRecorder auditContract.IRecorder
+}
+
+func NewRoleController(roleRepo contract.IRoleRepository, auditRecorder auditContract.IRecorder) *RoleController {
+	return &RoleController{roleRepo, auditRecorder}
+}
+
+func (c *RoleController) List(w http.ResponseWriter, r *http.Request) {
+	roles, err := c.roleRepo.GetRoles()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as roles")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, roles)
+}
+
+func (c *RoleController) Get(w http.ResponseWriter, r *http.Request) {
+	roleId := chi.URLParam(r, "id")
+	role, err := c.roleRepo.GetRole(roleId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "role não encontrada")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, role)
+}
+
+func (c *RoleController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateRoleDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	role, err := entity.NewRole(input.Name, input.Description, toPermissions(input.Permissions))
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.roleRepo.CreateRole(*role); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar a role")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRoleCreate, "role", role.ID, nil, role)
+	response := map[string]string{
+		"id": role.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *RoleController) Update(w http.ResponseWriter, r *http.Request) {
+	roleId := chi.URLParam(r, "id")
+	var input dto.UpdateRoleDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	role, err := c.roleRepo.GetRole(roleId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a role não existe")
+		return
+	}
+
+	before := role
+
+	role.Name = input.Name
+	role.Description = input.Description
+	role.Permissions = toPermissions(input.Permissions)
+	if err := role.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.roleRepo.UpdateRole(role); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar a role")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRoleUpdate, "role", role.ID, before, role)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *RoleController) Delete(w http.ResponseWriter, r *http.Request) {
+	roleId := chi.URLParam(r, "id")
+	before, err := c.roleRepo.GetRole(roleId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a role não existe")
+		return
+	}
+	if err := c.roleRepo.DeleteRole(roleId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a role não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRoleDelete, "role", roleId, before, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toPermissions(values []string) []entity.Permission {
+	permissions := make([]entity.Permission, 0, len(values))
+	for _, value := range values {
+		permissions = append(permissions, entity.Permission(value))
+	}
+	return permissions
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type UserController struct {
+	userRepo      contract.IUserRepository
+	roleRepo      contract.IRoleRepository
+	auditRecorder auditContract.IRecorder
+}
+
+func NewUserController(userRepo contract.IUserRepository, roleRepo contract.IRoleRepository, auditRecorder auditContract.IRecorder) *UserController {
+	return &UserController{userRepo, roleRepo, auditRecorder}
+}
+
+func (c *UserController) List(w http.ResponseWriter, r *http.Request) {
+	users, err := c.userRepo.GetUsers()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os usuários")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, users)
+}
+
+func (c *UserController) Get(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	user, err := c.userRepo.GetUser(userId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "usuário não encontrado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, user)
+}
+
+func (c *UserController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateUserDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	user, err := entity.NewUser(input.Name, input.Email, input.Enabled)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.userRepo.CreateUser(*user); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o usuário")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionUserCreate, "user", user.ID, nil, user)
+	response := map[string]string{
+		"id": user.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *UserController) Update(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	var input dto.UpdateUserDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	user, err := c.userRepo.GetUser(userId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o usuário não existe")
+		return
+	}
+
+	before := user
+
+	user.Name = input.Name
+	user.Email = input.Email
+	user.Enabled = input.Enabled
+	if err := user.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.userRepo.UpdateUser(user); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o usuário")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionUserUpdate, "user", user.ID, before, user)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *UserController) Delete(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	before, err := c.userRepo.GetUser(userId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o usuário não existe")
+		return
+	}
+	if err := c.userRepo.DeleteUser(userId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o usuário não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionUserDelete, "user", userId, before, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *UserController) ListGrants(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	grants, err := c.userRepo.GetGrants(userId)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as concessões")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, grants)
+}
+
+// CreateGrant atribui uma role ao usuário, globalmente ou restrita a um datasource ou tag.
+func (c *UserController) CreateGrant(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	var input dto.CreateGrantDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if _, err := c.userRepo.GetUser(userId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o usuário não existe")
+		return
+	}
+	if _, err := c.roleRepo.GetRole(input.RoleId); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "role não encontrada")
+		return
+	}
+
+	grant, err := entity.NewGrant(userId, input.RoleId, input.DatasourceId, input.Tag)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.userRepo.CreateGrant(*grant); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar a concessão")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionGrantCreate, "grant", grant.ID, nil, grant)
+	response := map[string]string{
+		"id": grant.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *UserController) DeleteGrant(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "id")
+	grantId := chi.URLParam(r, "grantId")
+	if err := c.userRepo.DeleteGrant(userId, grantId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a concessão não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionGrantDelete, "grant", grantId, map[string]string{"id": grantId, "user_id": userId}, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
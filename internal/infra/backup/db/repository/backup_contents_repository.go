@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type BackupContentsRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IBackupContentsRepository = (*BackupContentsRepository)(nil)
+
+func NewBackupContentsRepository(db *sql.DB) *BackupContentsRepository {
+	return &BackupContentsRepository{db}
+}
+
+// GetBackupContents implements IBackupContentsRepository.
+func (repo *BackupContentsRepository) GetBackupContents(backupId string) (entity.BackupContents, error) {
+	var data []byte
+	err := repo.db.QueryRow(`
+		SELECT contents
+		FROM backup_contents
+		WHERE backup_id = $1::uuid
+	`, backupId).Scan(&data)
+	if err != nil {
+		return entity.BackupContents{}, err
+	}
+
+	var contents entity.BackupContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return entity.BackupContents{}, err
+	}
+	return contents, nil
+}
+
+// SaveBackupContents implements IBackupContentsRepository.
+func (repo *BackupContentsRepository) SaveBackupContents(contents entity.BackupContents) error {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		INSERT INTO backup_contents (backup_id, contents, computed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (backup_id) DO UPDATE SET contents = EXCLUDED.contents, computed_at = EXCLUDED.computed_at
+	`, contents.BackupId, data, contents.ComputedAt)
+	return err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+const backupProfileColumns = `id, name, description, cron_expr, backup_format, backup_jobs, compression_level, dump_filter, schema_only, retention_keep_last, retention_max_age_days, timeout_minutes, created_at, time_zone`
+
+type BackupProfileRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IBackupProfileRepository = (*BackupProfileRepository)(nil)
+
+func NewBackupProfileRepository(db *sql.DB) *BackupProfileRepository {
+	return &BackupProfileRepository{db}
+}
+
+// GetBackupProfiles implements IBackupProfileRepository.
+func (repo *BackupProfileRepository) GetBackupProfiles() ([]entity.BackupProfile, error) {
+	rows, err := repo.db.Query(`
+		SELECT ` + backupProfileColumns + `
+		FROM backup_profiles
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := make([]entity.BackupProfile, 0)
+	for rows.Next() {
+		profile, err := scanBackupProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// GetBackupProfile implements IBackupProfileRepository.
+func (repo *BackupProfileRepository) GetBackupProfile(entityID string) (entity.BackupProfile, error) {
+	row := repo.db.QueryRow(`
+		SELECT `+backupProfileColumns+`
+		FROM backup_profiles
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanBackupProfile(row)
+}
+
+// CreateBackupProfile implements IBackupProfileRepository.
+func (repo *BackupProfileRepository) CreateBackupProfile(entity entity.BackupProfile) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		INSERT INTO backup_profiles (`+backupProfileColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Description,
+		entity.CronExpr,
+		entity.BackupFormat,
+		entity.BackupJobs,
+		entity.CompressionLevel,
+		dumpFilter,
+		entity.SchemaOnly,
+		entity.Retention.KeepLast,
+		entity.Retention.MaxAgeDays,
+		entity.TimeoutMinutes,
+		entity.CreatedAt,
+		entity.TimeZone,
+	)
+	return err
+}
+
+// UpdateBackupProfile implements IBackupProfileRepository.
+func (repo *BackupProfileRepository) UpdateBackupProfile(entity entity.BackupProfile) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		UPDATE backup_profiles
+		SET name = $2, description = $3, cron_expr = $4, backup_format = $5, backup_jobs = $6, compression_level = $7,
+			dump_filter = $8, schema_only = $9, retention_keep_last = $10, retention_max_age_days = $11, timeout_minutes = $12,
+			time_zone = $13
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Name,
+		entity.Description,
+		entity.CronExpr,
+		entity.BackupFormat,
+		entity.BackupJobs,
+		entity.CompressionLevel,
+		dumpFilter,
+		entity.SchemaOnly,
+		entity.Retention.KeepLast,
+		entity.Retention.MaxAgeDays,
+		entity.TimeoutMinutes,
+		entity.TimeZone,
+	)
+	return err
+}
+
+// DeleteBackupProfile implements IBackupProfileRepository.
+func (repo *BackupProfileRepository) DeleteBackupProfile(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM backup_profiles
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanBackupProfile(row rowScanner) (entity.BackupProfile, error) {
+	var (
+		profile    entity.BackupProfile
+		dumpFilter []byte
+	)
+	err := row.Scan(
+		&profile.ID,
+		&profile.Name,
+		&profile.Description,
+		&profile.CronExpr,
+		&profile.BackupFormat,
+		&profile.BackupJobs,
+		&profile.CompressionLevel,
+		&dumpFilter,
+		&profile.SchemaOnly,
+		&profile.Retention.KeepLast,
+		&profile.Retention.MaxAgeDays,
+		&profile.TimeoutMinutes,
+		&profile.CreatedAt,
+		&profile.TimeZone,
+	)
+	if err != nil {
+		return entity.BackupProfile{}, err
+	}
+	if err := json.Unmarshal(dumpFilter, &profile.DumpFilter); err != nil {
+		return entity.BackupProfile{}, err
+	}
+	return profile, nil
+}
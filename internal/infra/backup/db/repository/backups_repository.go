@@ -2,11 +2,16 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
 )
 
+// backupColumns lista as colunas lidas por scanBackup, na mesma ordem.
+const backupColumns = `id, datasource_id, trigger, status, file_path, file_original_name, file_size, checksum, started_at, finished_at, restored_at, pre_restore_of, dump_filter, globals_file_path, server_version, client_version, schema_only, schedule_id, skipped_by`
+
 type BackupRepository struct {
 	db *sql.DB
 }
@@ -18,29 +23,12 @@ func NewBackupRepository(db *sql.DB) *BackupRepository {
 }
 
 func (b *BackupRepository) GetBackup(entityID string) (entity.Backup, error) {
-	backup := entity.Backup{}
-
 	row := b.db.QueryRow(`
-		SELECT id, datasource_id, trigger, status, file_path, file_original_name, file_size, started_at, finished_at, restored_at
+		SELECT `+backupColumns+`
 		FROM backups
 		WHERE id = $1::uuid
 	`, entityID)
-	err := row.Scan(
-		&backup.ID,
-		&backup.DatasourceId,
-		&backup.Trigger,
-		&backup.Status,
-		&backup.FilePath,
-		&backup.FileOriginalName,
-		&backup.FileSize,
-		&backup.StartedAt,
-		&backup.FinishedAt,
-		&backup.RestoredAt,
-	)
-	if err != nil {
-		return entity.Backup{}, err
-	}
-	return backup, nil
+	return scanBackup(row)
 }
 
 func (b *BackupRepository) GetBackups(datasourceId *string) ([]entity.Backup, error) {
@@ -51,13 +39,13 @@ func (b *BackupRepository) GetBackups(datasourceId *string) ([]entity.Backup, er
 
 	if datasourceId == nil {
 		rows, err = b.db.Query(`
-		SELECT id, datasource_id, trigger, status, file_path, file_original_name, file_size, started_at, finished_at, restored_at
+		SELECT ` + backupColumns + `
 		FROM backups
 		ORDER BY finished_at DESC;
 	`)
 	} else {
 		rows, err = b.db.Query(`
-		SELECT id, datasource_id, trigger, status, file_path, file_original_name, file_size, started_at, finished_at, restored_at
+		SELECT `+backupColumns+`
 		FROM backups
 		WHERE datasource_id = $1::uuid
 		ORDER BY finished_at DESC;
@@ -67,37 +55,44 @@ func (b *BackupRepository) GetBackups(datasourceId *string) ([]entity.Backup, er
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	backups := make([]entity.Backup, 0)
-	for rows.Next() {
-		var backup entity.Backup
-		err := rows.Scan(
-			&backup.ID,
-			&backup.DatasourceId,
-			&backup.Trigger,
-			&backup.Status,
-			&backup.FilePath,
-			&backup.FileOriginalName,
-			&backup.FileSize,
-			&backup.StartedAt,
-			&backup.FinishedAt,
-			&backup.RestoredAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		backups = append(backups, backup)
-	}
-	if err := rows.Err(); err != nil {
+	return scanBackups(rows)
+}
+
+// GetLatestBackups retorna o backup agendado ou manual mais recente de cada datasource que chegou ao fim, concluído
+// ou com falha. Backups em andamento, ignorados, pre-restore e importados não representam uma execução de backup.
+func (b *BackupRepository) GetLatestBackups() ([]entity.Backup, error) {
+	rows, err := b.db.Query(`
+		SELECT DISTINCT ON (datasource_id) ` + backupColumns + `
+		FROM backups
+		WHERE trigger IN ('cron', 'manual') AND status IN ('completed', 'failed')
+		ORDER BY datasource_id, started_at DESC NULLS LAST;
+	`)
+	if err != nil {
 		return nil, err
 	}
-	return backups, nil
+	return scanBackups(rows)
+}
+
+// GetPreviousCompletedBackup retorna o último backup concluído do datasource iniciado antes da data informada.
+func (b *BackupRepository) GetPreviousCompletedBackup(datasourceId string, before time.Time) (entity.Backup, error) {
+	row := b.db.QueryRow(`
+		SELECT `+backupColumns+`
+		FROM backups
+		WHERE datasource_id = $1::uuid AND status = 'completed' AND started_at < $2
+		ORDER BY started_at DESC
+		LIMIT 1
+	`, datasourceId, before)
+	return scanBackup(row)
 }
 
 func (b *BackupRepository) CreateBackup(entity entity.Backup) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
 	stmt, err := b.db.Prepare(`
-		INSERT INTO backups (id, datasource_id, trigger, status, file_path, file_original_name, file_size, started_at, finished_at, restored_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO backups (id, datasource_id, trigger, status, file_path, file_original_name, file_size, checksum, started_at, finished_at, restored_at, pre_restore_of, dump_filter, globals_file_path, server_version, client_version, schema_only, schedule_id, skipped_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 	`)
 	if err != nil {
 		return err
@@ -110,9 +105,18 @@ func (b *BackupRepository) CreateBackup(entity entity.Backup) error {
 		entity.FilePath,
 		entity.FileOriginalName,
 		entity.FileSize,
+		entity.Checksum,
 		entity.StartedAt,
 		entity.FinishedAt,
 		entity.RestoredAt,
+		entity.PreRestoreOf,
+		dumpFilter,
+		entity.GlobalsFilePath,
+		entity.ServerVersion,
+		entity.ClientVersion,
+		entity.SchemaOnly,
+		entity.ScheduleId,
+		entity.SkippedBy,
 	)
 	if err != nil {
 		return err
@@ -121,10 +125,15 @@ func (b *BackupRepository) CreateBackup(entity entity.Backup) error {
 }
 
 func (b *BackupRepository) UpdateBackup(entity entity.Backup) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
 	stmt, err := b.db.Prepare(`
 		UPDATE backups
-		SET trigger = $1, status = $2, file_path = $3, file_original_name = $4, file_size = $5, started_at = $6, finished_at = $7, restored_at = $8
-		WHERE id = $9::uuid
+		SET trigger = $1, status = $2, file_path = $3, file_original_name = $4, file_size = $5, checksum = $6, started_at = $7, finished_at = $8, restored_at = $9, pre_restore_of = $10, dump_filter = $11, globals_file_path = $12,
+		server_version = $13, client_version = $14
+		WHERE id = $15::uuid
 	`)
 	if err != nil {
 		return err
@@ -135,9 +144,15 @@ func (b *BackupRepository) UpdateBackup(entity entity.Backup) error {
 		entity.FilePath,
 		entity.FileOriginalName,
 		entity.FileSize,
+		entity.Checksum,
 		entity.StartedAt,
 		entity.FinishedAt,
 		entity.RestoredAt,
+		entity.PreRestoreOf,
+		dumpFilter,
+		entity.GlobalsFilePath,
+		entity.ServerVersion,
+		entity.ClientVersion,
 		entity.ID,
 	)
 	if err != nil {
@@ -153,3 +168,54 @@ func (b *BackupRepository) DeleteBackup(entityID string) error {
 	`, entityID)
 	return err
 }
+
+func scanBackups(rows *sql.Rows) ([]entity.Backup, error) {
+	defer rows.Close()
+	backups := make([]entity.Backup, 0)
+	for rows.Next() {
+		backup, err := scanBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+func scanBackup(row rowScanner) (entity.Backup, error) {
+	var (
+		backup     entity.Backup
+		dumpFilter []byte
+	)
+	err := row.Scan(
+		&backup.ID,
+		&backup.DatasourceId,
+		&backup.Trigger,
+		&backup.Status,
+		&backup.FilePath,
+		&backup.FileOriginalName,
+		&backup.FileSize,
+		&backup.Checksum,
+		&backup.StartedAt,
+		&backup.FinishedAt,
+		&backup.RestoredAt,
+		&backup.PreRestoreOf,
+		&dumpFilter,
+		&backup.GlobalsFilePath,
+		&backup.ServerVersion,
+		&backup.ClientVersion,
+		&backup.SchemaOnly,
+		&backup.ScheduleId,
+		&backup.SkippedBy,
+	)
+	if err != nil {
+		return entity.Backup{}, err
+	}
+	if err := json.Unmarshal(dumpFilter, &backup.DumpFilter); err != nil {
+		return entity.Backup{}, err
+	}
+	return backup, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+const blackoutWindowColumns = `id, datasource_id, name, cron_expr, duration_minutes, time_zone, action, created_at`
+
+type BlackoutWindowRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IBlackoutWindowRepository = (*BlackoutWindowRepository)(nil)
+
+func NewBlackoutWindowRepository(db *sql.DB) *BlackoutWindowRepository {
+	return &BlackoutWindowRepository{db}
+}
+
+// GetBlackoutWindows implements IBlackoutWindowRepository.
+func (repo *BlackoutWindowRepository) GetBlackoutWindows(datasourceId string) ([]entity.BlackoutWindow, error) {
+	rows, err := repo.db.Query(`
+		SELECT `+blackoutWindowColumns+`
+		FROM blackout_windows
+		WHERE datasource_id = $1::uuid
+		ORDER BY name
+	`, datasourceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	windows := make([]entity.BlackoutWindow, 0)
+	for rows.Next() {
+		window, err := scanBlackoutWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// GetBlackoutWindow implements IBlackoutWindowRepository.
+func (repo *BlackoutWindowRepository) GetBlackoutWindow(entityID string) (entity.BlackoutWindow, error) {
+	row := repo.db.QueryRow(`
+		SELECT `+blackoutWindowColumns+`
+		FROM blackout_windows
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanBlackoutWindow(row)
+}
+
+// CreateBlackoutWindow implements IBlackoutWindowRepository.
+func (repo *BlackoutWindowRepository) CreateBlackoutWindow(entity entity.BlackoutWindow) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO blackout_windows (`+blackoutWindowColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.Name,
+		entity.CronExpr,
+		entity.DurationMinutes,
+		entity.TimeZone,
+		entity.Action,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateBlackoutWindow implements IBlackoutWindowRepository.
+func (repo *BlackoutWindowRepository) UpdateBlackoutWindow(entity entity.BlackoutWindow) error {
+	_, err := repo.db.Exec(`
+		UPDATE blackout_windows
+		SET name = $2, cron_expr = $3, duration_minutes = $4, time_zone = $5, action = $6
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Name,
+		entity.CronExpr,
+		entity.DurationMinutes,
+		entity.TimeZone,
+		entity.Action,
+	)
+	return err
+}
+
+// DeleteBlackoutWindow implements IBlackoutWindowRepository.
+func (repo *BlackoutWindowRepository) DeleteBlackoutWindow(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM blackout_windows
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanBlackoutWindow(row rowScanner) (entity.BlackoutWindow, error) {
+	var window entity.BlackoutWindow
+	err := row.Scan(
+		&window.ID,
+		&window.DatasourceId,
+		&window.Name,
+		&window.CronExpr,
+		&window.DurationMinutes,
+		&window.TimeZone,
+		&window.Action,
+		&window.CreatedAt,
+	)
+	if err != nil {
+		return entity.BlackoutWindow{}, err
+	}
+	return window, nil
+}
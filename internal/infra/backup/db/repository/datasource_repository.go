@@ -2,10 +2,12 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"log"
 
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/lib/pq"
 )
 
 type DatasourceRepository struct {
@@ -20,10 +22,13 @@ func NewDatasourceRepository(db *sql.DB) *DatasourceRepository {
 
 // GetDatasource implements IDatasourceRepository.
 func (repo *DatasourceRepository) GetDatasource(entityID string) (entity.Datasource, error) {
-	var datasource entity.Datasource = entity.Datasource{Cron: &entity.CronExpr{}}
+	var (
+		datasource entity.Datasource = entity.Datasource{Cron: &entity.CronExpr{}}
+		dumpFilter []byte
+	)
 
 	row := repo.db.QueryRow(`
-		SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled
+		SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled, tags, protected, backup_format, backup_jobs, dump_filter, include_globals, profile_id, time_zone
 		FROM datasources
 		WHERE id = $1::uuid
 	`, entityID)
@@ -39,10 +44,21 @@ func (repo *DatasourceRepository) GetDatasource(entityID string) (entity.Datasou
 		&datasource.Cron.CronExpr,
 		&datasource.Cron.Description,
 		&datasource.Cron.Enabled,
+		pq.Array(&datasource.Tags),
+		&datasource.Protected,
+		&datasource.BackupFormat,
+		&datasource.BackupJobs,
+		&dumpFilter,
+		&datasource.IncludeGlobals,
+		&datasource.ProfileId,
+		&datasource.Cron.TimeZone,
 	)
 	if err != nil {
 		return entity.Datasource{}, err
 	}
+	if err := json.Unmarshal(dumpFilter, &datasource.DumpFilter); err != nil {
+		return entity.Datasource{}, err
+	}
 
 	return datasource, nil
 }
@@ -56,12 +72,12 @@ func (repo *DatasourceRepository) GetDatasources(enabled *bool) ([]entity.Dataso
 
 	if enabled == nil {
 		rows, err = repo.db.Query(`
-			SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled
+			SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled, tags, protected, backup_format, backup_jobs, dump_filter, include_globals, profile_id, time_zone
 			FROM datasources
 		`)
 	} else {
 		rows, err = repo.db.Query(`
-			SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled
+			SELECT id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled, tags, protected, backup_format, backup_jobs, dump_filter, include_globals, profile_id, time_zone
 			FROM datasources
 			WHERE enabled = true
 		`)
@@ -74,7 +90,10 @@ func (repo *DatasourceRepository) GetDatasources(enabled *bool) ([]entity.Dataso
 
 	var datasources []entity.Datasource = make([]entity.Datasource, 0)
 	for rows.Next() {
-		var datasource entity.Datasource = entity.Datasource{Cron: &entity.CronExpr{}}
+		var (
+			datasource entity.Datasource = entity.Datasource{Cron: &entity.CronExpr{}}
+			dumpFilter []byte
+		)
 		err := rows.Scan(
 			&datasource.ID,
 			&datasource.Host,
@@ -86,10 +105,21 @@ func (repo *DatasourceRepository) GetDatasources(enabled *bool) ([]entity.Dataso
 			&datasource.Cron.CronExpr,
 			&datasource.Cron.Description,
 			&datasource.Cron.Enabled,
+			pq.Array(&datasource.Tags),
+			&datasource.Protected,
+			&datasource.BackupFormat,
+			&datasource.BackupJobs,
+			&dumpFilter,
+			&datasource.IncludeGlobals,
+			&datasource.ProfileId,
+			&datasource.Cron.TimeZone,
 		)
 		if err != nil {
 			return []entity.Datasource{}, err
 		}
+		if err := json.Unmarshal(dumpFilter, &datasource.DumpFilter); err != nil {
+			return []entity.Datasource{}, err
+		}
 		datasources = append(datasources, datasource)
 	}
 
@@ -99,8 +129,8 @@ func (repo *DatasourceRepository) GetDatasources(enabled *bool) ([]entity.Dataso
 // CreateDatasource implements IDatasourceRepository.
 func (repo *DatasourceRepository) CreateDatasource(entity entity.Datasource) error {
 	stmt, err := repo.db.Prepare(`
-		INSERT INTO datasources (id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO datasources (id, host, database, port, username, password, ssl_mode, cron_expr, description, enabled, tags, protected, backup_format, backup_jobs, dump_filter, include_globals, profile_id, time_zone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`)
 	if err != nil {
 		return err
@@ -110,6 +140,10 @@ func (repo *DatasourceRepository) CreateDatasource(entity entity.Datasource) err
 	if err != nil {
 		return err
 	}
+	dumpFilter, err := json.Marshal(datasource.DumpFilter)
+	if err != nil {
+		return err
+	}
 	_, err = stmt.Exec(
 		datasource.ID,
 		datasource.Host,
@@ -121,6 +155,14 @@ func (repo *DatasourceRepository) CreateDatasource(entity entity.Datasource) err
 		datasource.Cron.CronExpr,
 		datasource.Cron.Description,
 		datasource.Cron.Enabled,
+		pq.Array(datasource.Tags),
+		datasource.Protected,
+		datasource.BackupFormat,
+		datasource.BackupJobs,
+		dumpFilter,
+		datasource.IncludeGlobals,
+		datasource.ProfileId,
+		datasource.Cron.TimeZone,
 	)
 	if err != nil {
 		return err
@@ -137,9 +179,14 @@ func (repo *DatasourceRepository) UpdateDatasource(entity entity.Datasource) err
 
 	log.Printf("Updating -> %+v", datasource)
 
+	dumpFilter, err := json.Marshal(datasource.DumpFilter)
+	if err != nil {
+		return err
+	}
+
 	stmt, err := repo.db.Prepare(`
 		UPDATE datasources
-		SET host=$2, database=$3, port=$4, username=$5, password=$6, ssl_mode=$7, cron_expr=$8, description=$9, enabled=$10
+		SET host=$2, database=$3, port=$4, username=$5, password=$6, ssl_mode=$7, cron_expr=$8, description=$9, enabled=$10, tags=$11, protected=$12, backup_format=$13, backup_jobs=$14, dump_filter=$15, include_globals=$16, profile_id=$17, time_zone=$18
 		WHERE id = $1::uuid
 	`)
 	if err != nil {
@@ -156,6 +203,14 @@ func (repo *DatasourceRepository) UpdateDatasource(entity entity.Datasource) err
 		datasource.Cron.CronExpr,
 		datasource.Cron.Description,
 		datasource.Cron.Enabled,
+		pq.Array(datasource.Tags),
+		datasource.Protected,
+		datasource.BackupFormat,
+		datasource.BackupJobs,
+		dumpFilter,
+		datasource.IncludeGlobals,
+		datasource.ProfileId,
+		datasource.Cron.TimeZone,
 	)
 	if err != nil {
 		return err
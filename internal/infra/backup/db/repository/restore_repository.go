@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+const restoreColumns = "id, backup_id, datasource_id, database, mode, scope, recovery_target, status, requested_by, requested_by_name, restore_request_id, pre_restore_backup_id, temporary_database_id, error, output, started_at, finished_at"
+
+type RestoreRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IRestoreRepository = (*RestoreRepository)(nil)
+
+func NewRestoreRepository(db *sql.DB) *RestoreRepository {
+	return &RestoreRepository{db}
+}
+
+// GetRestores implements IRestoreRepository.
+func (repo *RestoreRepository) GetRestores(filter dto.RestoreFilter) ([]entity.Restore, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.DatasourceId != "" {
+		addCondition("datasource_id = $%d::uuid", filter.DatasourceId)
+	}
+	if filter.BackupId != "" {
+		addCondition("backup_id = $%d::uuid", filter.BackupId)
+	}
+	if filter.Status != "" {
+		addCondition("status = $%d", filter.Status)
+	}
+
+	query := "SELECT " + restoreColumns + " FROM restores"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY started_at DESC"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	restores := make([]entity.Restore, 0)
+	for rows.Next() {
+		restore, err := scanRestore(rows)
+		if err != nil {
+			return nil, err
+		}
+		restores = append(restores, restore)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return restores, nil
+}
+
+// GetRestore implements IRestoreRepository.
+func (repo *RestoreRepository) GetRestore(entityID string) (entity.Restore, error) {
+	row := repo.db.QueryRow("SELECT "+restoreColumns+" FROM restores WHERE id = $1::uuid", entityID)
+	return scanRestore(row)
+}
+
+// CreateRestore implements IRestoreRepository.
+func (repo *RestoreRepository) CreateRestore(entity entity.Restore) error {
+	scope, err := json.Marshal(entity.Scope)
+	if err != nil {
+		return err
+	}
+	// Sem ponto de recuperação, a coluna fica NULL.
+	var recoveryTarget any
+	if entity.RecoveryTarget != nil {
+		if recoveryTarget, err = json.Marshal(entity.RecoveryTarget); err != nil {
+			return err
+		}
+	}
+	_, err = repo.db.Exec(`
+		INSERT INTO restores (`+restoreColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`,
+		entity.ID,
+		entity.BackupId,
+		entity.DatasourceId,
+		entity.Database,
+		entity.Mode,
+		scope,
+		recoveryTarget,
+		entity.Status,
+		entity.RequestedBy,
+		entity.RequestedByName,
+		entity.RestoreRequestId,
+		entity.PreRestoreBackupId,
+		entity.TemporaryDatabaseId,
+		entity.Error,
+		entity.Output,
+		entity.StartedAt,
+		entity.FinishedAt,
+	)
+	return err
+}
+
+// UpdateRestore implements IRestoreRepository.
+func (repo *RestoreRepository) UpdateRestore(entity entity.Restore) error {
+	_, err := repo.db.Exec(`
+		UPDATE restores
+		SET database = $2, status = $3, pre_restore_backup_id = $4, temporary_database_id = $5, error = $6, output = $7, finished_at = $8
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Database,
+		entity.Status,
+		entity.PreRestoreBackupId,
+		entity.TemporaryDatabaseId,
+		entity.Error,
+		entity.Output,
+		entity.FinishedAt,
+	)
+	return err
+}
+
+func scanRestore(row rowScanner) (entity.Restore, error) {
+	var (
+		restore        entity.Restore
+		scope          []byte
+		recoveryTarget []byte
+	)
+	err := row.Scan(
+		&restore.ID,
+		&restore.BackupId,
+		&restore.DatasourceId,
+		&restore.Database,
+		&restore.Mode,
+		&scope,
+		&recoveryTarget,
+		&restore.Status,
+		&restore.RequestedBy,
+		&restore.RequestedByName,
+		&restore.RestoreRequestId,
+		&restore.PreRestoreBackupId,
+		&restore.TemporaryDatabaseId,
+		&restore.Error,
+		&restore.Output,
+		&restore.StartedAt,
+		&restore.FinishedAt,
+	)
+	if err != nil {
+		return entity.Restore{}, err
+	}
+	if err := json.Unmarshal(scope, &restore.Scope); err != nil {
+		return entity.Restore{}, err
+	}
+	if recoveryTarget != nil {
+		if err := json.Unmarshal(recoveryTarget, &restore.RecoveryTarget); err != nil {
+			return entity.Restore{}, err
+		}
+	}
+	return restore, nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type RestoreRequestRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IRestoreRequestRepository = (*RestoreRequestRepository)(nil)
+
+func NewRestoreRequestRepository(db *sql.DB) *RestoreRequestRepository {
+	return &RestoreRequestRepository{db}
+}
+
+// GetRestoreRequests implements IRestoreRequestRepository.
+func (repo *RestoreRequestRepository) GetRestoreRequests(status *entity.RestoreRequestStatus) ([]entity.RestoreRequest, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if status == nil {
+		rows, err = repo.db.Query(`
+			SELECT id, backup_id, datasource_id, status, reason, scope, requested_by, requested_by_name, decided_by, decided_by_name, decision_comment, decided_at, expires_at, created_at
+			FROM restore_requests
+			ORDER BY created_at DESC
+		`)
+	} else {
+		rows, err = repo.db.Query(`
+			SELECT id, backup_id, datasource_id, status, reason, scope, requested_by, requested_by_name, decided_by, decided_by_name, decision_comment, decided_at, expires_at, created_at
+			FROM restore_requests
+			WHERE status = $1
+			ORDER BY created_at DESC
+		`, *status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]entity.RestoreRequest, 0)
+	for rows.Next() {
+		request, err := scanRestoreRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetRestoreRequest implements IRestoreRequestRepository.
+func (repo *RestoreRequestRepository) GetRestoreRequest(entityID string) (entity.RestoreRequest, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, backup_id, datasource_id, status, reason, scope, requested_by, requested_by_name, decided_by, decided_by_name, decision_comment, decided_at, expires_at, created_at
+		FROM restore_requests
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanRestoreRequest(row)
+}
+
+// CreateRestoreRequest implements IRestoreRequestRepository.
+func (repo *RestoreRequestRepository) CreateRestoreRequest(entity entity.RestoreRequest) error {
+	scope, err := json.Marshal(entity.Scope)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		INSERT INTO restore_requests (id, backup_id, datasource_id, status, reason, scope, requested_by, requested_by_name, decided_by, decided_by_name, decision_comment, decided_at, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		entity.ID,
+		entity.BackupId,
+		entity.DatasourceId,
+		entity.Status,
+		entity.Reason,
+		scope,
+		entity.RequestedBy,
+		entity.RequestedByName,
+		entity.DecidedBy,
+		entity.DecidedByName,
+		entity.DecisionComment,
+		entity.DecidedAt,
+		entity.ExpiresAt,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateRestoreRequest implements IRestoreRequestRepository.
+//
+// A atualização só acontece enquanto a solicitação estiver pendente no banco, para que decisões concorrentes não
+// sejam aplicadas duas vezes; nesse caso, retorna ErrRestoreRequestNotPending.
+func (repo *RestoreRequestRepository) UpdateRestoreRequest(request entity.RestoreRequest) error {
+	result, err := repo.db.Exec(`
+		UPDATE restore_requests
+		SET status = $2, decided_by = $3, decided_by_name = $4, decision_comment = $5, decided_at = $6
+		WHERE id = $1::uuid AND status = $7
+	`,
+		request.ID,
+		request.Status,
+		request.DecidedBy,
+		request.DecidedByName,
+		request.DecisionComment,
+		request.DecidedAt,
+		entity.RestoreRequestPending,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return entity.ErrRestoreRequestNotPending
+	}
+	return nil
+}
+
+// ExpireRestoreRequests implements IRestoreRequestRepository.
+func (repo *RestoreRequestRepository) ExpireRestoreRequests(now time.Time) error {
+	_, err := repo.db.Exec(`
+		UPDATE restore_requests
+		SET status = $1
+		WHERE status = $2 AND expires_at IS NOT NULL AND expires_at < $3
+	`, entity.RestoreRequestExpired, entity.RestoreRequestPending, now)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRestoreRequest(row rowScanner) (entity.RestoreRequest, error) {
+	var (
+		request entity.RestoreRequest
+		scope   []byte
+	)
+	err := row.Scan(
+		&request.ID,
+		&request.BackupId,
+		&request.DatasourceId,
+		&request.Status,
+		&request.Reason,
+		&scope,
+		&request.RequestedBy,
+		&request.RequestedByName,
+		&request.DecidedBy,
+		&request.DecidedByName,
+		&request.DecisionComment,
+		&request.DecidedAt,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+	)
+	if err != nil {
+		return entity.RestoreRequest{}, err
+	}
+	if err := json.Unmarshal(scope, &request.Scope); err != nil {
+		return entity.RestoreRequest{}, err
+	}
+	return request, nil
+}
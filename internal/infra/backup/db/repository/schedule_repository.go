@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+const scheduleColumns = `id, datasource_id, name, cron_expr, time_zone, enabled, backup_format, backup_jobs, compression_level, dump_filter, schema_only, retention_keep_last, retention_max_age_days, timeout_minutes, created_at`
+
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IScheduleRepository = (*ScheduleRepository)(nil)
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepository {
+	return &ScheduleRepository{db}
+}
+
+// GetSchedules implements IScheduleRepository.
+func (repo *ScheduleRepository) GetSchedules(datasourceId *string) ([]entity.Schedule, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if datasourceId == nil {
+		rows, err = repo.db.Query(`
+			SELECT ` + scheduleColumns + `
+			FROM schedules
+			ORDER BY datasource_id, name
+		`)
+	} else {
+		rows, err = repo.db.Query(`
+			SELECT `+scheduleColumns+`
+			FROM schedules
+			WHERE datasource_id = $1::uuid
+			ORDER BY name
+		`, *datasourceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]entity.Schedule, 0)
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetSchedule implements IScheduleRepository.
+func (repo *ScheduleRepository) GetSchedule(entityID string) (entity.Schedule, error) {
+	row := repo.db.QueryRow(`
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanSchedule(row)
+}
+
+// CreateSchedule implements IScheduleRepository.
+func (repo *ScheduleRepository) CreateSchedule(entity entity.Schedule) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		INSERT INTO schedules (`+scheduleColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.Name,
+		entity.CronExpr,
+		entity.TimeZone,
+		entity.Enabled,
+		entity.BackupFormat,
+		entity.BackupJobs,
+		entity.CompressionLevel,
+		dumpFilter,
+		entity.SchemaOnly,
+		entity.Retention.KeepLast,
+		entity.Retention.MaxAgeDays,
+		entity.TimeoutMinutes,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateSchedule implements IScheduleRepository.
+func (repo *ScheduleRepository) UpdateSchedule(entity entity.Schedule) error {
+	dumpFilter, err := json.Marshal(entity.DumpFilter)
+	if err != nil {
+		return err
+	}
+	_, err = repo.db.Exec(`
+		UPDATE schedules
+		SET name = $2, cron_expr = $3, time_zone = $4, enabled = $5, backup_format = $6, backup_jobs = $7, compression_level = $8,
+			dump_filter = $9, schema_only = $10, retention_keep_last = $11, retention_max_age_days = $12, timeout_minutes = $13
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Name,
+		entity.CronExpr,
+		entity.TimeZone,
+		entity.Enabled,
+		entity.BackupFormat,
+		entity.BackupJobs,
+		entity.CompressionLevel,
+		dumpFilter,
+		entity.SchemaOnly,
+		entity.Retention.KeepLast,
+		entity.Retention.MaxAgeDays,
+		entity.TimeoutMinutes,
+	)
+	return err
+}
+
+// DeleteSchedule implements IScheduleRepository.
+func (repo *ScheduleRepository) DeleteSchedule(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM schedules
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanSchedule(row rowScanner) (entity.Schedule, error) {
+	var (
+		schedule   entity.Schedule
+		dumpFilter []byte
+	)
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.DatasourceId,
+		&schedule.Name,
+		&schedule.CronExpr,
+		&schedule.TimeZone,
+		&schedule.Enabled,
+		&schedule.BackupFormat,
+		&schedule.BackupJobs,
+		&schedule.CompressionLevel,
+		&dumpFilter,
+		&schedule.SchemaOnly,
+		&schedule.Retention.KeepLast,
+		&schedule.Retention.MaxAgeDays,
+		&schedule.TimeoutMinutes,
+		&schedule.CreatedAt,
+	)
+	if err != nil {
+		return entity.Schedule{}, err
+	}
+	if err := json.Unmarshal(dumpFilter, &schedule.DumpFilter); err != nil {
+		return entity.Schedule{}, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type TemporaryDatabaseRepository struct {
+	db *sql.DB
+}
+
+var _ contract.ITemporaryDatabaseRepository = (*TemporaryDatabaseRepository)(nil)
+
+func NewTemporaryDatabaseRepository(db *sql.DB) *TemporaryDatabaseRepository {
+	return &TemporaryDatabaseRepository{db}
+}
+
+// GetTemporaryDatabases implements ITemporaryDatabaseRepository.
+func (repo *TemporaryDatabaseRepository) GetTemporaryDatabases() ([]entity.TemporaryDatabase, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, datasource_id, backup_id, database, registered_datasource_id, expires_at, created_at, dropped_at, error
+		FROM temporary_databases
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanTemporaryDatabases(rows)
+}
+
+// GetTemporaryDatabase implements ITemporaryDatabaseRepository.
+func (repo *TemporaryDatabaseRepository) GetTemporaryDatabase(entityID string) (entity.TemporaryDatabase, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, backup_id, database, registered_datasource_id, expires_at, created_at, dropped_at, error
+		FROM temporary_databases
+		WHERE id = $1::uuid
+	`, entityID)
+	return scanTemporaryDatabase(row)
+}
+
+// GetExpiredTemporaryDatabases implements ITemporaryDatabaseRepository.
+func (repo *TemporaryDatabaseRepository) GetExpiredTemporaryDatabases(now time.Time) ([]entity.TemporaryDatabase, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, datasource_id, backup_id, database, registered_datasource_id, expires_at, created_at, dropped_at, error
+		FROM temporary_databases
+		WHERE dropped_at IS NULL AND expires_at <= $1
+		ORDER BY expires_at
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	return scanTemporaryDatabases(rows)
+}
+
+// CreateTemporaryDatabase implements ITemporaryDatabaseRepository.
+func (repo *TemporaryDatabaseRepository) CreateTemporaryDatabase(entity entity.TemporaryDatabase) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO temporary_databases (id, datasource_id, backup_id, database, registered_datasource_id, expires_at, created_at, dropped_at, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.BackupId,
+		entity.Database,
+		entity.RegisteredDatasourceId,
+		entity.ExpiresAt,
+		entity.CreatedAt,
+		entity.DroppedAt,
+		entity.Error,
+	)
+	return err
+}
+
+// UpdateTemporaryDatabase implements ITemporaryDatabaseRepository.
+func (repo *TemporaryDatabaseRepository) UpdateTemporaryDatabase(entity entity.TemporaryDatabase) error {
+	_, err := repo.db.Exec(`
+		UPDATE temporary_databases
+		SET registered_datasource_id = $2, expires_at = $3, dropped_at = $4, error = $5
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.RegisteredDatasourceId,
+		entity.ExpiresAt,
+		entity.DroppedAt,
+		entity.Error,
+	)
+	return err
+}
+
+func scanTemporaryDatabases(rows *sql.Rows) ([]entity.TemporaryDatabase, error) {
+	defer rows.Close()
+	databases := make([]entity.TemporaryDatabase, 0)
+	for rows.Next() {
+		database, err := scanTemporaryDatabase(rows)
+		if err != nil {
+			return nil, err
+		}
+		databases = append(databases, database)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+func scanTemporaryDatabase(row rowScanner) (entity.TemporaryDatabase, error) {
+	var database entity.TemporaryDatabase
+	err := row.Scan(
+		&database.ID,
+		&database.DatasourceId,
+		&database.BackupId,
+		&database.Database,
+		&database.RegisteredDatasourceId,
+		&database.ExpiresAt,
+		&database.CreatedAt,
+		&database.DroppedAt,
+		&database.Error,
+	)
+	if err != nil {
+		return entity.TemporaryDatabase{}, err
+	}
+	return database, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+)
+
+type WalSegmentRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IWalSegmentRepository = (*WalSegmentRepository)(nil)
+
+func NewWalSegmentRepository(db *sql.DB) *WalSegmentRepository {
+	return &WalSegmentRepository{db}
+}
+
+// GetWalSegments implements IWalSegmentRepository.
+func (repo *WalSegmentRepository) GetWalSegments(datasourceId string) ([]entity.WalSegment, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, datasource_id, file_name, file_path, file_size, checksum, archived_at
+		FROM wal_segments
+		WHERE datasource_id = $1::uuid
+		ORDER BY archived_at, file_name
+	`, datasourceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := make([]entity.WalSegment, 0)
+	for rows.Next() {
+		segment, err := scanWalSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// GetWalSegment implements IWalSegmentRepository.
+func (repo *WalSegmentRepository) GetWalSegment(datasourceId, fileName string) (entity.WalSegment, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, file_name, file_path, file_size, checksum, archived_at
+		FROM wal_segments
+		WHERE datasource_id = $1::uuid AND file_name = $2
+	`, datasourceId, fileName)
+	return scanWalSegment(row)
+}
+
+// GetLatestWalSegment implements IWalSegmentRepository.
+func (repo *WalSegmentRepository) GetLatestWalSegment(datasourceId string) (entity.WalSegment, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, file_name, file_path, file_size, checksum, archived_at
+		FROM wal_segments
+		WHERE datasource_id = $1::uuid
+		ORDER BY archived_at DESC
+		LIMIT 1
+	`, datasourceId)
+	return scanWalSegment(row)
+}
+
+// CreateWalSegment implements IWalSegmentRepository.
+func (repo *WalSegmentRepository) CreateWalSegment(entity entity.WalSegment) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO wal_segments (id, datasource_id, file_name, file_path, file_size, checksum, archived_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.FileName,
+		entity.FilePath,
+		entity.FileSize,
+		entity.Checksum,
+		entity.ArchivedAt,
+	)
+	return err
+}
+
+func scanWalSegment(row rowScanner) (entity.WalSegment, error) {
+	var segment entity.WalSegment
+	err := row.Scan(
+		&segment.ID,
+		&segment.DatasourceId,
+		&segment.FileName,
+		&segment.FilePath,
+		&segment.FileSize,
+		&segment.Checksum,
+		&segment.ArchivedAt,
+	)
+	if err != nil {
+		return entity.WalSegment{}, err
+	}
+	return segment, nil
+}
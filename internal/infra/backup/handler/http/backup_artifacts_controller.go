@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+// signedDownloadKey marca no contexto as requisições autorizadas por uma URL de download assinada.
+type signedDownloadKey struct{}
+
+type BackupArtifactsController struct {
+	backupRepo     contract.IBackupRepository
+	datasourceRepo contract.IDatasourceRepository
+	artifacts      contract.IBackupArtifacts
+	signer         contract.IDownloadSigner
+	auditRecorder  auditContract.IRecorder
+}
+
+func NewBackupArtifactsController(backupRepo contract.IBackupRepository, datasourceRepo contract.IDatasourceRepository, artifacts contract.IBackupArtifacts, signer contract.IDownloadSigner, auditRecorder auditContract.IRecorder) *BackupArtifactsController {
+	return &BackupArtifactsController{backupRepo, datasourceRepo, artifacts, signer, auditRecorder}
+}
+
+// AllowSignedURL aceita requisições com uma URL assinada válida (parâmetros expires e signature) sem
+// autenticação; as demais passam pelos middlewares informados.
+func (c *BackupArtifactsController) AllowSignedURL(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticated := next
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			authenticated = middlewares[i](authenticated)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if !query.Has("signature") {
+				authenticated.ServeHTTP(w, r)
+				return
+			}
+			if !c.signer.Verify(chi.URLParam(r, "id"), query.Get("expires"), query.Get("signature")) {
+				utils.JSONError(w, http.StatusForbidden, "url de download inválida ou expirada")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signedDownloadKey{}, true)))
+		})
+	}
+}
+
+// Download envia o arquivo do backup, com suporte a Range/If-Range, e os cabeçalhos de checksum
+// X-Checksum-SHA256 (hex), Digest (sha-256 em base64) e ETag. Aceita autenticação ou uma URL assinada.
+func (c *BackupArtifactsController) Download(w http.ResponseWriter, r *http.Request) {
+	signed, _ := r.Context().Value(signedDownloadKey{}).(bool)
+	backup, ok := c.load(w, r, !signed)
+	if !ok {
+		return
+	}
+
+	file, err := os.Open(backup.FilePath)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "arquivo do backup não encontrado")
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	backup, err = c.artifacts.Checksum(backup)
+	if err != nil {
+		log.Printf("erro ao calcular o checksum do backup %s: %v", backup.ID, err)
+	}
+	if backup.Checksum != "" {
+		w.Header().Set("ETag", fmt.Sprintf("%q", backup.Checksum))
+		w.Header().Set("X-Checksum-SHA256", backup.Checksum)
+		if sum, err := hex.DecodeString(backup.Checksum); err == nil {
+			w.Header().Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(sum))
+		}
+	}
+
+	// Downloads retomados (Range) não geram um novo registro de auditoria; o das URLs assinadas é feito na emissão.
+	if !signed && r.Header.Get("Range") == "" {
+		c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupDownload, "backup", backup.ID, nil, map[string]any{
+			"datasource_id": backup.DatasourceId,
+			"file":          filepath.Base(backup.FilePath),
+		})
+	}
+
+	name := filepath.Base(backup.FilePath)
+	contentType := "application/gzip"
+	if strings.HasSuffix(name, ".tar") {
+		contentType = "application/x-tar"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
+
+// DownloadURL emite uma URL de download assinada, válida por BACKUP_DOWNLOAD_URL_TTL, que pode ser
+// usada sem credenciais (ex: curl ou wget em outro servidor).
+func (c *BackupArtifactsController) DownloadURL(w http.ResponseWriter, r *http.Request) {
+	backup, ok := c.load(w, r, true)
+	if !ok {
+		return
+	}
+
+	signature, expiresAt := c.signer.Sign(backup.ID)
+	url := fmt.Sprintf("/v1/backups/%s/download?expires=%d&signature=%s", backup.ID, expiresAt.Unix(), signature)
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupDownload, "backup", backup.ID, nil, map[string]any{
+		"datasource_id": backup.DatasourceId,
+		"file":          filepath.Base(backup.FilePath),
+		"signed_url":    true,
+		"expires_at":    expiresAt,
+	})
+
+	utils.JSONResponse(w, http.StatusOK, map[string]any{
+		"url":        url,
+		"expires_at": expiresAt,
+	})
+}
+
+// Import recebe um arquivo .sql.gz, .backup.gz, .tar.gz ou .dir.tar (multipart/form-data, campo "file") para o datasource
+// informado em "datasourceId" e o registra no catálogo com o trigger import. O arquivo é gravado em fluxo.
+func (c *BackupArtifactsController) Import(w http.ResponseWriter, r *http.Request) {
+	datasource, err := c.datasourceRepo.GetDatasource(r.URL.Query().Get("datasourceId"))
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermBackupCreate, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "envie o arquivo como multipart/form-data no campo file")
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, "envie o arquivo como multipart/form-data no campo file")
+			return
+		}
+		if err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, "multipart inválido")
+			return
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		backup, err := c.artifacts.Import(datasource, part.FileName(), part)
+		part.Close()
+		if errors.Is(err, entity.ErrInvalidBackupFile) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupImport, "backup", backup.ID, nil, backup)
+		utils.JSONResponse(w, http.StatusCreated, backup)
+		return
+	}
+}
+
+// load carrega o backup concluído da rota. Com checkPermission, verifica a permissão de leitura sobre o
+// seu datasource. Em caso de falha a resposta já é escrita.
+func (c *BackupArtifactsController) load(w http.ResponseWriter, r *http.Request, checkPermission bool) (entity.Backup, bool) {
+	backup, err := c.backupRepo.GetBackup(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return entity.Backup{}, false
+	}
+	if checkPermission {
+		ds, err := c.datasourceRepo.GetDatasource(backup.DatasourceId)
+		if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+			utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+			return entity.Backup{}, false
+		}
+	}
+	if backup.Status != entity.BackupCompleted {
+		utils.JSONError(w, http.StatusConflict, "o backup não foi concluído")
+		return entity.Backup{}, false
+	}
+	return backup, true
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/pkg/pgdump"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type BackupContentsController struct {
+	backupRepo         contract.IBackupRepository
+	datasourceRepo     contract.IDatasourceRepository
+	backupContentsRepo contract.IBackupContentsRepository
+	backupService      contract.IBackupService
+	auditRecorder      auditContract.IRecorder
+}
+
+func NewBackupContentsController(backupRepo contract.IBackupRepository, datasourceRepo contract.IDatasourceRepository, backupContentsRepo contract.IBackupContentsRepository, backupService contract.IBackupService, auditRecorder auditContract.IRecorder) *BackupContentsController {
+	return &BackupContentsController{backupRepo, datasourceRepo, backupContentsRepo, backupService, auditRecorder}
+}
+
+// Contents retorna os schemas, tabelas, views, funções e índices do backup, com o tamanho aproximado
+// dos dados de cada tabela. O resultado é calculado na primeira consulta e mantido em cache.
+func (c *BackupContentsController) Contents(w http.ResponseWriter, r *http.Request) {
+	backup, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+
+	contents, err := c.backupContentsRepo.GetBackupContents(backup.ID)
+	if err == nil {
+		utils.JSONResponse(w, http.StatusOK, contents)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("erro ao carregar o conteúdo em cache do backup %s: %v", backup.ID, err)
+	}
+
+	contents, err = c.backupService.Contents(backup.FilePath)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	contents.BackupId = backup.ID
+	if err := c.backupContentsRepo.SaveBackupContents(contents); err != nil {
+		log.Printf("erro ao salvar o conteúdo do backup %s: %v", backup.ID, err)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, contents)
+}
+
+// ExportTable extrai os dados de uma tabela do backup, sem restaurá-lo, e os envia como download em CSV
+// (padrão) ou JSON delimitado por linhas (?format=ndjson). A tabela pode ser qualificada pelo schema
+// ("schema.tabela"); sem schema, é usada a primeira tabela com o nome informado.
+func (c *BackupContentsController) ExportTable(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "ndjson" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "formato inválido, use csv ou ndjson")
+		return
+	}
+	table := chi.URLParam(r, "table")
+	if table == "" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "tabela não informada")
+		return
+	}
+
+	backup, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+
+	reader, err := c.backupService.OpenTableData(backup.FilePath, table)
+	if errors.Is(err, pgdump.ErrTableNotFound) {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupExport, "backup", backup.ID, nil, map[string]string{
+		"table":  reader.Schema() + "." + reader.Table(),
+		"format": format,
+	})
+
+	filename := fmt.Sprintf("%s-%s.%s", backup.ID, exportFileName(reader.Schema()+"."+reader.Table()), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "ndjson" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		err = reader.WriteNDJSON(w)
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err = reader.WriteCSV(w)
+	}
+	// Com a resposta já iniciada, resta apenas registrar a falha; o download fica incompleto.
+	if err != nil {
+		log.Printf("erro ao exportar a tabela %s do backup %s: %v", table, backup.ID, err)
+	}
+}
+
+// exportFileName mantém no nome do arquivo apenas letras, números, ponto, hífen e sublinhado.
+func exportFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// load carrega o backup concluído da rota, verificando a permissão de leitura sobre o seu datasource.
+// Em caso de falha a resposta já é escrita.
+func (c *BackupContentsController) load(w http.ResponseWriter, r *http.Request) (entity.Backup, bool) {
+	backup, err := c.backupRepo.GetBackup(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return entity.Backup{}, false
+	}
+	ds, err := c.datasourceRepo.GetDatasource(backup.DatasourceId)
+	if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return entity.Backup{}, false
+	}
+	if backup.Status != entity.BackupCompleted {
+		utils.JSONError(w, http.StatusConflict, "o backup não foi concluído")
+		return entity.Backup{}, false
+	}
+	if backup.IsPhysical() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, entity.ErrPhysicalBackup.Error())
+		return entity.Backup{}, false
+	}
+	return backup, true
+}
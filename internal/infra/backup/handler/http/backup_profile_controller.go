@@ -0,0 +1,157 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type BackupProfileController struct {
+	profileRepo    contract.IBackupProfileRepository
+	datasourceRepo contract.IDatasourceRepository
+	auditRecorder  auditContract.IRecorder
+}
+
+func NewBackupProfileController(profileRepo contract.IBackupProfileRepository, datasourceRepo contract.IDatasourceRepository, auditRecorder auditContract.IRecorder) *BackupProfileController {
+	return &BackupProfileController{profileRepo, datasourceRepo, auditRecorder}
+}
+
+func (c *BackupProfileController) List(w http.ResponseWriter, r *http.Request) {
+	profiles, err := c.profileRepo.GetBackupProfiles()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os perfis de backup")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, profiles)
+}
+
+func (c *BackupProfileController) Get(w http.ResponseWriter, r *http.Request) {
+	profileId := chi.URLParam(r, "id")
+	profile, err := c.profileRepo.GetBackupProfile(profileId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "perfil de backup não encontrado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, profile)
+}
+
+func (c *BackupProfileController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateBackupProfileDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	profile, err := entity.NewBackupProfile(input.Name, input.Description, input.CronExpr, input.TimeZone, backupOptions(input.BackupOptionsDto))
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.profileRepo.CreateBackupProfile(*profile); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o perfil de backup")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupProfileCreate, "backup_profile", profile.ID, nil, profile)
+	response := map[string]string{
+		"id": profile.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+// Update altera o perfil e, com ele, o agendamento e as opções de todos os datasources que o referenciam.
+func (c *BackupProfileController) Update(w http.ResponseWriter, r *http.Request) {
+	profileId := chi.URLParam(r, "id")
+	var input dto.UpdateBackupProfileDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	profile, err := c.profileRepo.GetBackupProfile(profileId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o perfil de backup não existe")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	before := profile
+
+	profile.Name = input.Name
+	profile.Description = input.Description
+	profile.CronExpr = input.CronExpr
+	profile.TimeZone = input.TimeZone
+	profile.BackupOptions = backupOptions(input.BackupOptionsDto)
+	if err := profile.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.profileRepo.UpdateBackupProfile(profile); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o perfil de backup")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupProfileUpdate, "backup_profile", profile.ID, before, profile)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete remove o perfil apenas se nenhum datasource o referencia.
+func (c *BackupProfileController) Delete(w http.ResponseWriter, r *http.Request) {
+	profileId := chi.URLParam(r, "id")
+	before, err := c.profileRepo.GetBackupProfile(profileId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o perfil de backup não existe")
+		return
+	}
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível verificar os datasources do perfil")
+		return
+	}
+	inUse := slices.ContainsFunc(datasources, func(ds entity.Datasource) bool {
+		return ds.ProfileId != nil && *ds.ProfileId == profileId
+	})
+	if inUse {
+		utils.JSONError(w, http.StatusConflict, "o perfil de backup é usado por datasources")
+		return
+	}
+
+	if err := c.profileRepo.DeleteBackupProfile(profileId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o perfil de backup não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupProfileDelete, "backup_profile", profileId, before, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func backupOptions(input dto.BackupOptionsDto) entity.BackupOptions {
+	return entity.BackupOptions{
+		BackupFormat:     entity.BackupFormat(strings.TrimSpace(input.BackupFormat)),
+		BackupJobs:       input.BackupJobs,
+		CompressionLevel: input.CompressionLevel,
+		DumpFilter:       dumpFilter(input.DumpFilter),
+		SchemaOnly:       input.SchemaOnly,
+		Retention:        entity.Retention{KeepLast: input.Retention.KeepLast, MaxAgeDays: input.Retention.MaxAgeDays},
+		TimeoutMinutes:   input.TimeoutMinutes,
+	}
+}
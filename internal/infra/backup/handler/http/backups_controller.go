@@ -2,26 +2,38 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	notificationEntity "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
 	"github.com/bvaledev/database-backup-management-be/internal/utils"
 	"github.com/go-chi/chi"
 )
 
 type BackupsController struct {
-	backupRepo     contract.IBackupRepository
-	datasourceRepo contract.IDatasourceRepository
-	backupService  contract.IBackupService
-	backupCommand  contract.ICommand
+	backupRepo         contract.IBackupRepository
+	datasourceRepo     contract.IDatasourceRepository
+	restoreRequestRepo contract.IRestoreRequestRepository
+	backupCommand      contract.ICommand
+	restoreRunner      contract.IRestoreRunner
+	notifier           notificationContract.INotifier
+	auditRecorder      auditContract.IRecorder
 }
 
-func NewBackupController(backupRepo contract.IBackupRepository, datasourceRepo contract.IDatasourceRepository, backupService contract.IBackupService, backupCommand contract.ICommand) *BackupsController {
-	return &BackupsController{backupRepo, datasourceRepo, backupService, backupCommand}
+func NewBackupController(backupRepo contract.IBackupRepository, datasourceRepo contract.IDatasourceRepository, restoreRequestRepo contract.IRestoreRequestRepository, backupCommand contract.ICommand, restoreRunner contract.IRestoreRunner, notifier notificationContract.INotifier, auditRecorder auditContract.IRecorder) *BackupsController {
+	return &BackupsController{backupRepo, datasourceRepo, restoreRequestRepo, backupCommand, restoreRunner, notifier, auditRecorder}
 }
 
 func (c *BackupsController) List(w http.ResponseWriter, r *http.Request) {
@@ -41,7 +53,24 @@ func (c *BackupsController) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.JSONResponse(w, http.StatusOK, backups)
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os backups")
+		return
+	}
+	readable := make(map[string]bool, len(datasources))
+	for _, datasource := range datasources {
+		readable[datasource.ID] = auth.Can(r.Context(), authEntity.PermBackupRead, datasource.ID, datasource.Tags)
+	}
+
+	visible := make([]entity.Backup, 0, len(backups))
+	for _, backup := range backups {
+		if readable[backup.DatasourceId] {
+			visible = append(visible, backup)
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, visible)
 }
 
 func (c *BackupsController) Get(w http.ResponseWriter, r *http.Request) {
@@ -51,6 +80,10 @@ func (c *BackupsController) Get(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
 		return
 	}
+	if _, ok := c.authorize(r, authEntity.PermBackupRead, backup.DatasourceId); !ok {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
 
 	utils.JSONResponse(w, http.StatusOK, backup)
 }
@@ -63,12 +96,22 @@ func (c *BackupsController) CreateBackup(w http.ResponseWriter, r *http.Request)
 	}
 
 	datasource, err := c.datasourceRepo.GetDatasource(input.DatasourceId)
-	if err != nil {
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
 		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
 		return
 	}
+	if !auth.Can(r.Context(), authEntity.PermBackupCreate, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
 
 	go c.backupCommand.Command(datasource, entity.BackupManual)()
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupCreate, "datasource", datasource.ID, nil, map[string]any{
+		"datasource_id": datasource.ID,
+		"database":      datasource.Database,
+		"host":          datasource.Host,
+		"trigger":       entity.BackupManual,
+	})
 
 	response := map[string]string{
 		"datasource_id": datasource.ID,
@@ -78,18 +121,47 @@ func (c *BackupsController) CreateBackup(w http.ResponseWriter, r *http.Request)
 	utils.JSONResponse(w, http.StatusOK, response)
 }
 
+// RestoreBackup restaura o backup no datasource de origem ou no informado em "datasourceId".
+//
+// Em datasources protegidos a restauração não é executada: é criada uma solicitação pendente
+// que precisa ser aprovada por outro usuário em POST /v1/restore-requests/{id}/approve.
+//
+// Com "mode": "new_database" o datasource não é alterado: o backup é restaurado em um novo banco
+// no mesmo servidor, removido automaticamente após o ttl. Nesse modo não há aprovação nem snapshot.
+//
+// Backups físicos (pg_basebackup) exigem "mode": "data_directory": o backup é extraído em um novo
+// diretório de dados no servidor da aplicação, sem alterar nenhum banco, aprovação ou snapshot.
 func (c *BackupsController) RestoreBackup(w http.ResponseWriter, r *http.Request) {
 	var (
-		ds  entity.Datasource
-		err error
+		ds    entity.Datasource
+		err   error
+		input dto.RestoreBackupDto
 	)
 
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if input.ExpiresInMinutes < 0 {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "expires_in_minutes inválido")
+		return
+	}
+	options, err := restoreOptions(input)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
 	backupId := chi.URLParam(r, "id")
 	backup, err := c.backupRepo.GetBackup(backupId)
 	if err != nil {
 		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
 		return
 	}
+	if _, ok := c.authorize(r, authEntity.PermBackupRead, backup.DatasourceId); !ok {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
 
 	datasourceId := r.URL.Query().Get("datasourceId")
 	if datasourceId == "" {
@@ -100,33 +172,164 @@ func (c *BackupsController) RestoreBackup(w http.ResponseWriter, r *http.Request
 		}
 	} else {
 		ds, err = c.datasourceRepo.GetDatasource(datasourceId)
-		if err != nil {
+		if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, ds.ID, ds.Tags) {
 			utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
 			return
 		}
 	}
+	if !auth.Can(r.Context(), authEntity.PermBackupRestore, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente para restaurar neste datasource")
+		return
+	}
+	if backup.Status != entity.BackupCompleted {
+		utils.JSONError(w, http.StatusConflict, "o backup não foi concluído")
+		return
+	}
+	if backup.IsPhysical() && !options.IsDataDirectory() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, entity.ErrPhysicalBackup.Error())
+		return
+	}
+	if !backup.IsPhysical() && options.IsDataDirectory() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "o modo data_directory exige um backup físico (pg_basebackup)")
+		return
+	}
+	if options.IsDataDirectory() {
+		c.restoreDataDirectory(w, r, options, backup, ds)
+		return
+	}
 
-	go func(backupRepo contract.IBackupRepository, backup entity.Backup, ds entity.Datasource) {
-		decodedDs, err := ds.Decode()
-		if err != nil {
-			log.Printf("erro ao decodificar datasource: %v", err)
-			return
-		}
+	if err := c.restoreRunner.CheckScope(backup, options.Scope); err != nil {
+		c.restoreError(w, err)
+		return
+	}
 
-		_, err = c.backupService.Restore(decodedDs, backup.FilePath)
-		if err != nil {
-			log.Printf("erro ao restaurar o backup: %v", err)
-			return
-		}
-		backup.SetRestoredAt()
-		backupRepo.UpdateBackup(backup)
-	}(c.backupRepo, backup, ds)
+	if options.IsNewDatabase() {
+		c.restoreNewDatabase(w, r, options, backup, ds)
+		return
+	}
+	c.startRestore(w, r, input, options.Scope, backup, ds, auditEntity.ActionBackupRestore)
+}
 
-	response := map[string]string{
-		"message": "restauração iniciada",
+// Rollback desfaz uma restauração, restaurando o snapshot de segurança (trigger pre-restore)
+// no datasource de onde ele foi gerado. Segue as mesmas regras de proteção da restauração.
+func (c *BackupsController) Rollback(w http.ResponseWriter, r *http.Request) {
+	var input dto.RestoreBackupDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
 	}
 
-	utils.JSONResponse(w, http.StatusOK, response)
+	options, err := restoreOptions(input)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if options.IsNewDatabase() || options.IsDataDirectory() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "o rollback só pode sobrescrever o datasource de origem")
+		return
+	}
+	if !options.Scope.IsEmpty() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "o rollback restaura o snapshot completo")
+		return
+	}
+
+	snapshot, err := c.backupRepo.GetBackup(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
+	ds, ok := c.authorize(r, authEntity.PermBackupRead, snapshot.DatasourceId)
+	if !ok {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
+	if snapshot.Trigger != entity.BackupPreRestore {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "apenas snapshots anteriores a uma restauração (trigger pre-restore) podem ser usados no rollback")
+		return
+	}
+	if snapshot.Status != entity.BackupCompleted {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "o snapshot não foi concluído")
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermBackupRestore, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente para restaurar neste datasource")
+		return
+	}
+
+	c.startRestore(w, r, input, options.Scope, snapshot, ds, auditEntity.ActionBackupRollback)
+}
+
+// startRestore inicia a restauração ou, em datasources protegidos, cria a solicitação de aprovação.
+func (c *BackupsController) startRestore(w http.ResponseWriter, r *http.Request, input dto.RestoreBackupDto, scope entity.RestoreScope, backup entity.Backup, ds entity.Datasource, action auditEntity.Action) {
+	if ds.Protected {
+		c.requestRestore(w, r, input, scope, backup, ds)
+		return
+	}
+
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	restore := entity.NewRestore(backup.ID, ds, entity.RestoreOverwrite, scope, principal.Identity(), principal.Name)
+	restored, err := c.restoreRunner.Run(*restore, backup, ds)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível iniciar a restauração")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), action, "backup", backup.ID, nil, restoreAuditView(restored, backup))
+
+	response := map[string]any{
+		"message":    "restauração iniciada",
+		"restore_id": restored.ID,
+		"status":     restored.Status,
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, response)
+}
+
+// restoreNewDatabase cria o banco de destino e inicia a restauração nele.
+func (c *BackupsController) restoreNewDatabase(w http.ResponseWriter, r *http.Request, options entity.RestoreOptions, backup entity.Backup, ds entity.Datasource) {
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	restore := entity.NewRestore(backup.ID, ds, entity.RestoreNewDatabase, options.Scope, principal.Identity(), principal.Name)
+	restored, database, err := c.restoreRunner.RunNewDatabase(*restore, backup, ds, options)
+	if err != nil {
+		c.restoreError(w, err)
+		return
+	}
+
+	view := restoreAuditView(restored, backup)
+	view["expires_at"] = database.ExpiresAt
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupRestore, "backup", backup.ID, nil, view)
+
+	response := map[string]any{
+		"message":               "restauração iniciada em um novo banco",
+		"restore_id":            restored.ID,
+		"status":                restored.Status,
+		"temporary_database_id": database.ID,
+		"database":              database.Database,
+		"expires_at":            database.ExpiresAt,
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, response)
+}
+
+// restoreDataDirectory inicia a extração do backup físico em um novo diretório de dados.
+func (c *BackupsController) restoreDataDirectory(w http.ResponseWriter, r *http.Request, options entity.RestoreOptions, backup entity.Backup, ds entity.Datasource) {
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	restore := entity.NewRestore(backup.ID, ds, entity.RestoreDataDirectory, options.Scope, principal.Identity(), principal.Name)
+	restored, err := c.restoreRunner.RunDataDirectory(*restore, backup, ds, options)
+	if err != nil {
+		c.restoreError(w, err)
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupRestore, "backup", backup.ID, nil, restoreAuditView(restored, backup))
+
+	response := map[string]any{
+		"message":         "restauração iniciada em um novo diretório de dados",
+		"restore_id":      restored.ID,
+		"status":          restored.Status,
+		"data_directory":  restored.Database,
+		"recovery_target": restored.RecoveryTarget,
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, response)
 }
 
 func (c *BackupsController) Delete(w http.ResponseWriter, r *http.Request) {
@@ -138,21 +341,140 @@ func (c *BackupsController) Delete(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusNotFound, "o backup não existe")
 		return
 	}
+	if _, ok := c.authorize(r, authEntity.PermBackupRead, backup.DatasourceId); !ok {
+		utils.JSONError(w, http.StatusNotFound, "o backup não existe")
+		return
+	}
+	if _, ok := c.authorize(r, authEntity.PermBackupDelete, backup.DatasourceId); !ok {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
 
-	// verifica se o arquivo de backup existe
-	if _, err := os.Stat(backup.FilePath); os.IsExist(err) {
-		err := os.Remove(backup.FilePath)
-		if err != nil {
-			utils.JSONError(w, http.StatusNotFound, "não foi possível deletar o arquivo de backup")
+	// remove o arquivo (ou diretório, em backups físicos) do backup; um arquivo já ausente não impede a remoção
+	if backup.FilePath != "" {
+		if err := os.RemoveAll(backup.FilePath); err != nil && !os.IsNotExist(err) {
+			utils.JSONError(w, http.StatusInternalServerError, "não foi possível deletar o arquivo de backup")
 			return
 		}
 	}
 
+	if backup.GlobalsFilePath != "" {
+		if err := os.Remove(backup.GlobalsFilePath); err != nil && !os.IsNotExist(err) {
+			log.Printf("erro ao remover o arquivo de objetos globais %s: %v", backup.GlobalsFilePath, err)
+		}
+	}
+
 	err = c.backupRepo.DeleteBackup(backup.ID)
 	if err != nil {
 		utils.JSONError(w, http.StatusNotFound, "não foi possível deletar o backup")
 		return
 	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupDelete, "backup", backup.ID, backup, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// requestRestore cria a solicitação de restauração para um datasource protegido e notifica os aprovadores.
+// O solicitante precisa ser um usuário cadastrado, para que a aprovação seja feita por outra pessoa.
+func (c *BackupsController) requestRestore(w http.ResponseWriter, r *http.Request, input dto.RestoreBackupDto, scope entity.RestoreScope, backup entity.Backup, ds entity.Datasource) {
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	if principal.UserId == nil {
+		utils.JSONError(w, http.StatusForbidden, entity.ErrRestoreRequestNoUser.Error())
+		return
+	}
+	request := entity.NewRestoreRequest(backup.ID, ds.ID, input.Reason, principal.Identity(), principal.Name, time.Duration(input.ExpiresInMinutes)*time.Minute)
+	request.Scope = scope
+	if err := c.restoreRequestRepo.CreateRestoreRequest(*request); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível registrar a solicitação de restauração")
+		return
+	}
+
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRestoreRequest, "restore_request", request.ID, nil, request)
+	c.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRestoreRequested, ds, &backup).WithRestoreRequest(*request))
+
+	response := map[string]any{
+		"message":            "o datasource é protegido: a restauração aguarda aprovação de outro usuário",
+		"restore_request_id": request.ID,
+		"status":             request.Status,
+		"expires_at":         request.ExpiresAt,
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, response)
+}
+
+// restoreError responde 422 para opções de restauração inválidas e 500 para as demais falhas.
+func (c *BackupsController) restoreError(w http.ResponseWriter, err error) {
+	if errors.Is(err, entity.ErrInvalidRestoreOptions) {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err.Error())
+}
+
+// restoreOptions converte o corpo da requisição de restauração nas opções do runner.
+func restoreOptions(input dto.RestoreBackupDto) (entity.RestoreOptions, error) {
+	options := entity.RestoreOptions{
+		Mode:               entity.RestoreOverwrite,
+		TargetDatabase:     input.DatabaseName,
+		RegisterDatasource: input.RegisterDatasource,
+		DataDirectory:      input.DataDirectory,
+		Scope: entity.RestoreScope{
+			Schemas:        input.Schemas,
+			ExcludeSchemas: input.ExcludeSchemas,
+			Tables:         input.Tables,
+			ExcludeTables:  input.ExcludeTables,
+			DataOnly:       input.DataOnly,
+			SchemaOnly:     input.SchemaOnly,
+			SkipClear:      input.SkipClear,
+			SkipGlobals:    input.SkipGlobals,
+		},
+	}
+	if input.Mode != "" {
+		options.Mode = entity.RestoreMode(input.Mode)
+	}
+	if input.RecoveryTargetTime != nil || input.RecoveryTargetLSN != "" {
+		options.RecoveryTarget = &entity.RecoveryTarget{Time: input.RecoveryTargetTime, LSN: input.RecoveryTargetLSN}
+	}
+	if input.TTLHours < 0 {
+		return options, errors.New("ttl_hours inválido")
+	}
+	options.TTL = time.Duration(input.TTLHours) * time.Hour
+
+	if !options.IsNewDatabase() {
+		if input.TTLHours != 0 {
+			return options, errors.New("ttl_hours só se aplica ao modo new_database")
+		}
+		// O nome padrão do diretório de dados é definido pelo runner, que valida as opções.
+		if options.IsDataDirectory() {
+			return options, nil
+		}
+		return options, options.Validate()
+	}
+	return options, nil
+}
+
+// restoreAuditView descreve a restauração no registro de auditoria: backup de origem e destino.
+func restoreAuditView(restore entity.Restore, backup entity.Backup) map[string]any {
+	return map[string]any{
+		"restore_id":            restore.ID,
+		"backup_id":             backup.ID,
+		"source_datasource_id":  backup.DatasourceId,
+		"file_path":             backup.FilePath,
+		"mode":                  restore.Mode,
+		"scope":                 restore.Scope,
+		"recovery_target":       restore.RecoveryTarget,
+		"target_datasource_id":  restore.DatasourceId,
+		"target_database":       restore.Database,
+		"temporary_database_id": restore.TemporaryDatabaseId,
+		"restore_request_id":    restore.RestoreRequestId,
+	}
+}
+
+// authorize carrega o datasource e verifica se o principal da requisição possui a permissão sobre ele.
+func (c *BackupsController) authorize(r *http.Request, permission authEntity.Permission, datasourceId string) (entity.Datasource, bool) {
+	datasource, err := c.datasourceRepo.GetDatasource(datasourceId)
+	if err != nil {
+		return entity.Datasource{}, false
+	}
+	return datasource, auth.Can(r.Context(), permission, datasource.ID, datasource.Tags)
+}
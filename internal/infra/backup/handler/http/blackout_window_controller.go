@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+// BlackoutWindowController gerencia as janelas de bloqueio de um datasource (/v1/datasources/{id}/blackout-windows).
+type BlackoutWindowController struct {
+	blackoutRepo   contract.IBlackoutWindowRepository
+	datasourceRepo contract.IDatasourceRepository
+	auditRecorder  auditContract.IRecorder
+}
+
+func NewBlackoutWindowController(blackoutRepo contract.IBlackoutWindowRepository, datasourceRepo contract.IDatasourceRepository, auditRecorder auditContract.IRecorder) *BlackoutWindowController {
+	return &BlackoutWindowController{blackoutRepo, datasourceRepo, auditRecorder}
+}
+
+func (c *BlackoutWindowController) List(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceRead)
+	if !ok {
+		return
+	}
+
+	windows, err := c.blackoutRepo.GetBlackoutWindows(datasource.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as janelas de bloqueio")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, windows)
+}
+
+func (c *BlackoutWindowController) Create(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	var input dto.CreateBlackoutWindowDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	window, err := entity.NewBlackoutWindow(datasource.ID, input.Name, input.CronExpr, input.DurationMinutes, input.TimeZone, entity.BlackoutAction(input.Action))
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.blackoutRepo.CreateBlackoutWindow(*window); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar a janela de bloqueio")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBlackoutWindowCreate, "blackout_window", window.ID, nil, window)
+	response := map[string]string{
+		"id": window.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *BlackoutWindowController) Update(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	var input dto.UpdateBlackoutWindowDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	window, err := c.blackoutRepo.GetBlackoutWindow(chi.URLParam(r, "windowId"))
+	if err != nil || window.DatasourceId != datasource.ID {
+		utils.JSONError(w, http.StatusNotFound, "a janela de bloqueio não existe")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	before := window
+
+	window.Name = input.Name
+	window.CronExpr = input.CronExpr
+	window.DurationMinutes = input.DurationMinutes
+	window.TimeZone = input.TimeZone
+	window.Action = entity.BlackoutAction(input.Action)
+	if err := window.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.blackoutRepo.UpdateBlackoutWindow(window); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar a janela de bloqueio")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBlackoutWindowUpdate, "blackout_window", window.ID, before, window)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *BlackoutWindowController) Delete(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	before, err := c.blackoutRepo.GetBlackoutWindow(chi.URLParam(r, "windowId"))
+	if err != nil || before.DatasourceId != datasource.ID {
+		utils.JSONError(w, http.StatusNotFound, "a janela de bloqueio não existe")
+		return
+	}
+
+	if err := c.blackoutRepo.DeleteBlackoutWindow(before.ID); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "a janela de bloqueio não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBlackoutWindowDelete, "blackout_window", before.ID, before, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
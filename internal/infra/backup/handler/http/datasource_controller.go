@@ -2,9 +2,15 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
 	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
@@ -14,10 +20,12 @@ import (
 
 type DatasourceController struct {
 	datasourceRepo contract.IDatasourceRepository
+	profileRepo    contract.IBackupProfileRepository
+	auditRecorder  auditContract.IRecorder
 }
 
-func NewDatasourceController(datasourceRepo contract.IDatasourceRepository) *DatasourceController {
-	return &DatasourceController{datasourceRepo}
+func NewDatasourceController(datasourceRepo contract.IDatasourceRepository, profileRepo contract.IBackupProfileRepository, auditRecorder auditContract.IRecorder) *DatasourceController {
+	return &DatasourceController{datasourceRepo, profileRepo, auditRecorder}
 }
 
 func (c *DatasourceController) List(w http.ResponseWriter, r *http.Request) {
@@ -42,13 +50,20 @@ func (c *DatasourceController) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.JSONResponse(w, http.StatusOK, datasources)
+	visible := make([]entity.Datasource, 0, len(datasources))
+	for _, datasource := range datasources {
+		if auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
+			visible = append(visible, datasource)
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, visible)
 }
 
 func (c *DatasourceController) Get(w http.ResponseWriter, r *http.Request) {
 	datasourceId := chi.URLParam(r, "id")
 	datasource, err := c.datasourceRepo.GetDatasource(datasourceId)
-	if err != nil {
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
 		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
 		return
 	}
@@ -62,16 +77,42 @@ func (c *DatasourceController) Create(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
 		return
 	}
-	datasource, err := entity.NewDatasource(input.Host, input.Database, input.Username, input.Password, input.SSLMode, input.Port, input.Cron.CronExpr, input.Cron.Description, input.Cron.Enabled)
+	if err := backup.ValidateCronExpr(input.Cron.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+	datasource, err := entity.NewDatasource(input.Host, input.Database, input.Username, input.Password, input.SSLMode, input.Port, input.Cron.CronExpr, input.Cron.Description, input.Cron.Enabled, input.Tags)
 	if err != nil {
 		utils.JSONError(w, http.StatusUnprocessableEntity, "datasource inválido")
 		return
 	}
+	if !auth.Can(r.Context(), authEntity.PermDatasourceWrite, "", datasource.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+	datasource.Protected = input.Protected
+	if err := datasource.SetCronTimeZone(input.Cron.TimeZone); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := datasource.SetDumpOptions(entity.BackupFormat(input.BackupFormat), input.BackupJobs); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := datasource.SetDumpFilter(dumpFilter(input.DumpFilter)); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := setBackupProfile(c.profileRepo, datasource, input.ProfileId, input.IncludeGlobals); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
 	err = c.datasourceRepo.CreateDatasource(*datasource)
 	if err != nil {
 		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possivel cadastrar o datasource")
 		return
 	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionDatasourceCreate, "datasource", datasource.ID, nil, datasourceAuditView(*datasource))
 	response := map[string]string{
 		"id": datasource.ID,
 	}
@@ -87,10 +128,45 @@ func (c *DatasourceController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	datasource, err := c.datasourceRepo.GetDatasource(datasourceId)
-	if err != nil {
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
 		utils.JSONError(w, http.StatusNotFound, "o datasource não existe")
 		return
 	}
+	// Exige permissão sobre o datasource atual e sobre as novas tags, evitando que um usuário
+	// mova um datasource para fora (ou para dentro) de um escopo que não controla.
+	if !auth.Can(r.Context(), authEntity.PermDatasourceWrite, datasource.ID, datasource.Tags) ||
+		!auth.Can(r.Context(), authEntity.PermDatasourceWrite, datasource.ID, input.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+	// Remover a proteção dispensaria a aprovação de restaurações, por isso exige a permissão admin global: um admin
+	// restrito ao datasource poderia desprotegê-lo e restaurar sem a aprovação de outro usuário.
+	if input.Protected != datasource.Protected && !auth.CanAny(r.Context(), authEntity.PermAdmin) {
+		utils.JSONError(w, http.StatusForbidden, "apenas administradores podem alterar a proteção do datasource")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.Cron.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	before := datasourceAuditView(datasource)
+	if err := datasource.SetCronTimeZone(input.Cron.TimeZone); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := datasource.SetDumpOptions(entity.BackupFormat(input.BackupFormat), input.BackupJobs); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := datasource.SetDumpFilter(dumpFilter(input.DumpFilter)); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := setBackupProfile(c.profileRepo, &datasource, input.ProfileId, input.IncludeGlobals); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
 
 	datasource.Host = input.Host
 	datasource.Port = input.Port
@@ -101,22 +177,74 @@ func (c *DatasourceController) Update(w http.ResponseWriter, r *http.Request) {
 	datasource.Cron.CronExpr = input.Cron.CronExpr
 	datasource.Cron.Description = input.Cron.Description
 	datasource.Cron.Enabled = input.Cron.Enabled
+	datasource.SetTags(input.Tags)
+	datasource.Protected = input.Protected
 
 	err = c.datasourceRepo.UpdateDatasource(datasource)
 	if err != nil {
 		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o datasource")
 		return
 	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionDatasourceUpdate, "datasource", datasource.ID, before, datasourceAuditView(datasource))
 	w.WriteHeader(http.StatusNoContent)
 }
 
 func (c *DatasourceController) Delete(w http.ResponseWriter, r *http.Request) {
 	datasourceId := chi.URLParam(r, "id")
-	err := c.datasourceRepo.DeleteDatasource(datasourceId)
+	datasource, err := c.datasourceRepo.GetDatasource(datasourceId)
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "o datasource não existe")
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermDatasourceWrite, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+
+	err = c.datasourceRepo.DeleteDatasource(datasourceId)
 	if err != nil {
 		utils.JSONError(w, http.StatusNotFound, "o datasource não existe")
 		return
 	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionDatasourceDelete, "datasource", datasource.ID, datasourceAuditView(datasource), nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// setBackupProfile associa o perfil de backup ao datasource e define IncludeGlobals, validado contra o formato
+// efetivo: o do perfil, quando houver. Um id vazio remove o perfil.
+func setBackupProfile(profileRepo contract.IBackupProfileRepository, datasource *entity.Datasource, profileId *string, includeGlobals bool) error {
+	if profileId == nil || *profileId == "" {
+		datasource.ProfileId = nil
+		return datasource.SetIncludeGlobals(includeGlobals)
+	}
+	profile, err := profileRepo.GetBackupProfile(*profileId)
+	if err != nil {
+		return fmt.Errorf("perfil de backup não encontrado")
+	}
+	effective := profile.Apply(*datasource)
+	if err := effective.SetIncludeGlobals(includeGlobals); err != nil {
+		return err
+	}
+	datasource.ProfileId = &profile.ID
+	datasource.IncludeGlobals = includeGlobals
+	return nil
+}
+
+func dumpFilter(input dto.DumpFilterDto) entity.DumpFilter {
+	return entity.NewDumpFilter(input.Schemas, input.ExcludeSchemas, input.Tables, input.ExcludeTables, input.ExcludeTableData)
+}
+
+// datasourceAudit expõe a senha ao registro de auditoria apenas para que sua alteração seja detectada;
+// o valor é mascarado antes de ser gravado.
+type datasourceAudit struct {
+	entity.Datasource
+	Password string `json:"password"`
+}
+
+func datasourceAuditView(datasource entity.Datasource) datasourceAudit {
+	if decoded, err := datasource.Decode(); err == nil {
+		datasource = decoded
+	}
+	return datasourceAudit{datasource, datasource.Password}
+}
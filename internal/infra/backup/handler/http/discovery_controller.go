@@ -0,0 +1,203 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+)
+
+// discoveryDatabase é o banco usado na conexão da descoberta quando a requisição não informa outro.
+const discoveryDatabase = "postgres"
+
+type DiscoveryController struct {
+	datasourceRepo contract.IDatasourceRepository
+	profileRepo    contract.IBackupProfileRepository
+	backupService  contract.IBackupService
+	auditRecorder  auditContract.IRecorder
+}
+
+func NewDiscoveryController(datasourceRepo contract.IDatasourceRepository, profileRepo contract.IBackupProfileRepository, backupService contract.IBackupService, auditRecorder auditContract.IRecorder) *DiscoveryController {
+	return &DiscoveryController{datasourceRepo, profileRepo, backupService, auditRecorder}
+}
+
+// discoveredDatasource é um datasource cadastrado, ou já existente, na descoberta de um servidor.
+type discoveredDatasource struct {
+	Database     string `json:"database"`
+	DatasourceId string `json:"datasource_id,omitempty"`
+}
+
+type registerDatabasesResponse struct {
+	Created []discoveredDatasource `json:"created"`
+	// Skipped são os bancos que já tinham um datasource no mesmo host e porta.
+	Skipped []discoveredDatasource `json:"skipped"`
+}
+
+// Databases lista os bancos de um servidor (POST /v1/discovery/databases), indicando os que já têm datasource.
+func (c *DiscoveryController) Databases(w http.ResponseWriter, r *http.Request) {
+	var input dto.DiscoverDatabasesDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	databases, err := c.discover(r.Context(), input)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, databases)
+}
+
+// Register cadastra um datasource para cada banco escolhido do servidor (POST /v1/discovery/datasources), com
+// as credenciais, o cron e as opções de backup em comum. Os bancos precisam existir no servidor; os que já
+// têm datasource no mesmo host e porta são ignorados. As opções são validadas antes de qualquer cadastro.
+func (c *DiscoveryController) Register(w http.ResponseWriter, r *http.Request) {
+	var input dto.RegisterDatabasesDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if len(input.Databases) == 0 {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "informe os bancos a cadastrar")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.Cron.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermDatasourceWrite, "", input.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+
+	databases, err := c.discover(r.Context(), dto.DiscoverDatabasesDto{
+		Host:     input.Host,
+		Database: input.Database,
+		Port:     input.Port,
+		Username: input.Username,
+		Password: input.Password,
+		SSLMode:  input.SSLMode,
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	response := registerDatabasesResponse{Created: []discoveredDatasource{}, Skipped: []discoveredDatasource{}}
+	datasources := make([]*entity.Datasource, 0, len(input.Databases))
+	missing := make([]string, 0)
+	for _, name := range input.Databases {
+		index := slices.IndexFunc(databases, func(db entity.ServerDatabase) bool { return db.Name == name })
+		if index < 0 {
+			missing = append(missing, name)
+			continue
+		}
+		if databases[index].Registered {
+			skipped := discoveredDatasource{Database: name}
+			if databases[index].DatasourceId != nil {
+				skipped.DatasourceId = *databases[index].DatasourceId
+			}
+			response.Skipped = append(response.Skipped, skipped)
+			continue
+		}
+		if slices.ContainsFunc(datasources, func(ds *entity.Datasource) bool { return ds.Database == name }) {
+			continue
+		}
+
+		datasource, err := entity.NewDatasource(input.Host, name, input.Username, input.Password, input.SSLMode, input.Port, input.Cron.CronExpr, input.Cron.Description, input.Cron.Enabled, input.Tags)
+		if err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, "datasource inválido")
+			return
+		}
+		datasource.Protected = input.Protected
+		if err := datasource.SetCronTimeZone(input.Cron.TimeZone); err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := datasource.SetDumpOptions(entity.BackupFormat(input.BackupFormat), input.BackupJobs); err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := datasource.SetDumpFilter(dumpFilter(input.DumpFilter)); err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := setBackupProfile(c.profileRepo, datasource, input.ProfileId, input.IncludeGlobals); err != nil {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		datasources = append(datasources, datasource)
+	}
+	if len(missing) > 0 {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("bancos não encontrados no servidor: %s", strings.Join(missing, ", ")))
+		return
+	}
+
+	for _, datasource := range datasources {
+		if err := c.datasourceRepo.CreateDatasource(*datasource); err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("não foi possivel cadastrar o datasource do banco %s (%d cadastrados antes da falha)", datasource.Database, len(response.Created)))
+			return
+		}
+		c.auditRecorder.Record(r.Context(), auditEntity.ActionDatasourceCreate, "datasource", datasource.ID, nil, datasourceAuditView(*datasource))
+		response.Created = append(response.Created, discoveredDatasource{datasource.Database, datasource.ID})
+	}
+
+	status := http.StatusOK
+	if len(response.Created) > 0 {
+		status = http.StatusCreated
+	}
+	utils.JSONResponse(w, status, response)
+}
+
+// discover lista os bancos do servidor e associa os datasources já cadastrados no mesmo host e porta, inclusive
+// os fora do escopo do usuário, para que não sejam cadastrados em duplicidade; desses, o id não é exposto.
+func (c *DiscoveryController) discover(ctx context.Context, input dto.DiscoverDatabasesDto) ([]entity.ServerDatabase, error) {
+	if input.Host == "" || input.Port == 0 || input.Username == "" {
+		return nil, fmt.Errorf("informe host, porta e usuário do servidor")
+	}
+	ds := entity.Datasource{
+		Host:     input.Host,
+		Port:     input.Port,
+		Database: input.Database,
+		Username: input.Username,
+		Password: input.Password,
+		SSLMode:  input.SSLMode,
+	}
+	if ds.Database == "" {
+		ds.Database = discoveryDatabase
+	}
+
+	databases, err := c.backupService.ListDatabases(ds)
+	if err != nil {
+		return nil, err
+	}
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range databases {
+		for _, datasource := range datasources {
+			if datasource.SameDatabase(input.Host, input.Port, databases[i].Name) {
+				databases[i].Registered = true
+				if auth.Can(ctx, authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
+					databases[i].DatasourceId = &datasource.ID
+				}
+				break
+			}
+		}
+	}
+	return databases, nil
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	notificationContract "github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	notificationEntity "github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type RestoreRequestController struct {
+	restoreRequestRepo contract.IRestoreRequestRepository
+	backupRepo         contract.IBackupRepository
+	datasourceRepo     contract.IDatasourceRepository
+	restoreRunner      contract.IRestoreRunner
+	notifier           notificationContract.INotifier
+	auditRecorder      auditContract.IRecorder
+}
+
+func NewRestoreRequestController(restoreRequestRepo contract.IRestoreRequestRepository, backupRepo contract.IBackupRepository, datasourceRepo contract.IDatasourceRepository, restoreRunner contract.IRestoreRunner, notifier notificationContract.INotifier, auditRecorder auditContract.IRecorder) *RestoreRequestController {
+	return &RestoreRequestController{restoreRequestRepo, backupRepo, datasourceRepo, restoreRunner, notifier, auditRecorder}
+}
+
+func (c *RestoreRequestController) List(w http.ResponseWriter, r *http.Request) {
+	var status *entity.RestoreRequestStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		requestStatus := entity.RestoreRequestStatus(value)
+		status = &requestStatus
+	}
+
+	if err := c.restoreRequestRepo.ExpireRestoreRequests(time.Now()); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as solicitações de restauração")
+		return
+	}
+	requests, err := c.restoreRequestRepo.GetRestoreRequests(status)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as solicitações de restauração")
+		return
+	}
+
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as solicitações de restauração")
+		return
+	}
+	readable := make(map[string]bool, len(datasources))
+	for _, datasource := range datasources {
+		readable[datasource.ID] = auth.Can(r.Context(), authEntity.PermBackupRead, datasource.ID, datasource.Tags)
+	}
+
+	visible := make([]entity.RestoreRequest, 0, len(requests))
+	for _, request := range requests {
+		if readable[request.DatasourceId] {
+			visible = append(visible, request)
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, visible)
+}
+
+func (c *RestoreRequestController) Get(w http.ResponseWriter, r *http.Request) {
+	request, _, ok := c.load(w, r, authEntity.PermBackupRead)
+	if !ok {
+		return
+	}
+	if request.IsExpired(time.Now()) {
+		request.Status = entity.RestoreRequestExpired
+	}
+
+	utils.JSONResponse(w, http.StatusOK, request)
+}
+
+// Approve aprova a solicitação e inicia a restauração.
+//
+// O aprovador precisa da permissão de restauração sobre o datasource de destino e não pode ser
+// o mesmo usuário que criou a solicitação. Solicitante e aprovador precisam ser usuários cadastrados: chaves sem
+// usuário vinculado e a chave de bootstrap não aprovam, pois não identificam uma pessoa.
+// A solicitação é marcada como aprovada apenas se ainda estiver pendente, antes de iniciar a restauração.
+func (c *RestoreRequestController) Approve(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+	request, ds, ok := c.load(w, r, authEntity.PermBackupRestore)
+	if !ok {
+		return
+	}
+	if request.BackupId == nil {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
+	backup, err := c.backupRepo.GetBackup(*request.BackupId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "backup não encontrado")
+		return
+	}
+
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	if principal.UserId == nil || !authEntity.IsUserIdentity(request.RequestedBy) {
+		c.decisionError(w, entity.ErrRestoreRequestNoUser)
+		return
+	}
+
+	before := request
+	if err := request.Approve(principal.Identity(), principal.Name, input.Comment); err != nil {
+		if errors.Is(err, entity.ErrRestoreRequestExpired) {
+			c.restoreRequestRepo.UpdateRestoreRequest(request)
+		}
+		c.decisionError(w, err)
+		return
+	}
+	if err := c.restoreRequestRepo.UpdateRestoreRequest(request); err != nil {
+		if errors.Is(err, entity.ErrRestoreRequestNotPending) {
+			c.decisionError(w, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível aprovar a solicitação de restauração")
+		return
+	}
+
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRestoreApprove, "restore_request", request.ID, before, request)
+	c.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRestoreApproved, ds, &backup).WithRestoreRequest(request))
+
+	restore := entity.NewRestore(backup.ID, ds, entity.RestoreOverwrite, request.Scope, request.RequestedBy, request.RequestedByName)
+	restore.RestoreRequestId = &request.ID
+	restored, err := c.restoreRunner.Run(*restore, backup, ds)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "solicitação aprovada, mas não foi possível iniciar a restauração")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionBackupRestore, "backup", backup.ID, nil, restoreAuditView(restored, backup))
+
+	response := map[string]any{
+		"message":    "restauração aprovada e iniciada",
+		"restore_id": restored.ID,
+		"status":     restored.Status,
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// Reject rejeita a solicitação. Pode ser feito pelo próprio solicitante para cancelá-la. Assim como na
+// aprovação, a decisão exige um usuário cadastrado.
+func (c *RestoreRequestController) Reject(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+	request, ds, ok := c.load(w, r, authEntity.PermBackupRestore)
+	if !ok {
+		return
+	}
+
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	if principal.UserId == nil {
+		c.decisionError(w, entity.ErrRestoreRequestNoUser)
+		return
+	}
+
+	before := request
+	if err := request.Reject(principal.Identity(), principal.Name, input.Comment); err != nil {
+		c.decisionError(w, err)
+		return
+	}
+	if err := c.restoreRequestRepo.UpdateRestoreRequest(request); err != nil {
+		if errors.Is(err, entity.ErrRestoreRequestNotPending) {
+			c.decisionError(w, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível rejeitar a solicitação de restauração")
+		return
+	}
+
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionRestoreReject, "restore_request", request.ID, before, request)
+	var backupRef *entity.Backup
+	if request.BackupId != nil {
+		if backup, err := c.backupRepo.GetBackup(*request.BackupId); err == nil {
+			backupRef = &backup
+		}
+	}
+	c.notifier.Notify(notificationEntity.NewEvent(notificationEntity.EventRestoreRejected, ds, backupRef).WithRestoreRequest(request))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// load carrega a solicitação e o datasource de destino, verificando a permissão do principal sobre ele.
+// Em caso de falha a resposta já é escrita.
+func (c *RestoreRequestController) load(w http.ResponseWriter, r *http.Request, permission authEntity.Permission) (entity.RestoreRequest, entity.Datasource, bool) {
+	request, err := c.restoreRequestRepo.GetRestoreRequest(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "solicitação de restauração não encontrada")
+		return entity.RestoreRequest{}, entity.Datasource{}, false
+	}
+	ds, err := c.datasourceRepo.GetDatasource(request.DatasourceId)
+	if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "solicitação de restauração não encontrada")
+		return entity.RestoreRequest{}, entity.Datasource{}, false
+	}
+	if !auth.Can(r.Context(), permission, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return entity.RestoreRequest{}, entity.Datasource{}, false
+	}
+	return request, ds, true
+}
+
+func (c *RestoreRequestController) decisionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrRestoreRequestSelfReview), errors.Is(err, entity.ErrRestoreRequestNoUser):
+		utils.JSONError(w, http.StatusForbidden, err.Error())
+	default:
+		utils.JSONError(w, http.StatusConflict, err.Error())
+	}
+}
+
+func decodeDecision(w http.ResponseWriter, r *http.Request) (dto.DecideRestoreRequestDto, bool) {
+	var input dto.DecideRestoreRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return input, false
+	}
+	return input, true
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type RestoresController struct {
+	restoreRepo    contract.IRestoreRepository
+	datasourceRepo contract.IDatasourceRepository
+}
+
+func NewRestoresController(restoreRepo contract.IRestoreRepository, datasourceRepo contract.IDatasourceRepository) *RestoresController {
+	return &RestoresController{restoreRepo, datasourceRepo}
+}
+
+// List retorna o histórico de restaurações nos datasources visíveis ao principal,
+// filtrado por datasourceId, backupId e status.
+func (c *RestoresController) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	restores, err := c.restoreRepo.GetRestores(dto.RestoreFilter{
+		DatasourceId: query.Get("datasourceId"),
+		BackupId:     query.Get("backupId"),
+		Status:       query.Get("status"),
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as restaurações")
+		return
+	}
+
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as restaurações")
+		return
+	}
+	readable := make(map[string]bool, len(datasources))
+	for _, datasource := range datasources {
+		readable[datasource.ID] = auth.Can(r.Context(), authEntity.PermBackupRead, datasource.ID, datasource.Tags)
+	}
+
+	visible := make([]entity.Restore, 0, len(restores))
+	for _, restore := range restores {
+		if readable[restore.DatasourceId] {
+			visible = append(visible, restore)
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, visible)
+}
+
+func (c *RestoresController) Get(w http.ResponseWriter, r *http.Request) {
+	restore, err := c.restoreRepo.GetRestore(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "restauração não encontrada")
+		return
+	}
+	ds, err := c.datasourceRepo.GetDatasource(restore.DatasourceId)
+	if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "restauração não encontrada")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, restore)
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	"github.com/bvaledev/database-backup-management-be/internal/application/backup"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+// ScheduleController gerencia os agendamentos de um datasource (/v1/datasources/{id}/schedules).
+type ScheduleController struct {
+	scheduleRepo   contract.IScheduleRepository
+	datasourceRepo contract.IDatasourceRepository
+	auditRecorder  auditContract.IRecorder
+}
+
+func NewScheduleController(scheduleRepo contract.IScheduleRepository, datasourceRepo contract.IDatasourceRepository, auditRecorder auditContract.IRecorder) *ScheduleController {
+	return &ScheduleController{scheduleRepo, datasourceRepo, auditRecorder}
+}
+
+func (c *ScheduleController) List(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceRead)
+	if !ok {
+		return
+	}
+
+	schedules, err := c.scheduleRepo.GetSchedules(&datasource.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os agendamentos")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schedules)
+}
+
+func (c *ScheduleController) Create(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	var input dto.CreateScheduleDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	schedule, err := entity.NewSchedule(datasource.ID, input.Name, input.CronExpr, input.TimeZone, input.Enabled, backupOptions(input.BackupOptionsDto))
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.scheduleRepo.CreateSchedule(*schedule); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o agendamento")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionScheduleCreate, "schedule", schedule.ID, nil, schedule)
+	response := map[string]string{
+		"id": schedule.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *ScheduleController) Update(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	var input dto.UpdateScheduleDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	schedule, err := c.scheduleRepo.GetSchedule(chi.URLParam(r, "scheduleId"))
+	if err != nil || schedule.DatasourceId != datasource.ID {
+		utils.JSONError(w, http.StatusNotFound, "o agendamento não existe")
+		return
+	}
+	if err := backup.ValidateCronExpr(input.CronExpr); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("expressão cron inválida: %s", err))
+		return
+	}
+
+	before := schedule
+
+	schedule.Name = input.Name
+	schedule.CronExpr = input.CronExpr
+	schedule.TimeZone = input.TimeZone
+	schedule.Enabled = input.Enabled
+	schedule.BackupOptions = backupOptions(input.BackupOptionsDto)
+	if err := schedule.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.scheduleRepo.UpdateSchedule(schedule); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o agendamento")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionScheduleUpdate, "schedule", schedule.ID, before, schedule)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete remove o agendamento. Os backups gerados por ele são mantidos.
+func (c *ScheduleController) Delete(w http.ResponseWriter, r *http.Request) {
+	datasource, ok := routeDatasource(w, r, c.datasourceRepo, authEntity.PermDatasourceWrite)
+	if !ok {
+		return
+	}
+	before, err := c.scheduleRepo.GetSchedule(chi.URLParam(r, "scheduleId"))
+	if err != nil || before.DatasourceId != datasource.ID {
+		utils.JSONError(w, http.StatusNotFound, "o agendamento não existe")
+		return
+	}
+
+	if err := c.scheduleRepo.DeleteSchedule(before.ID); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o agendamento não existe")
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionScheduleDelete, "schedule", before.ID, before, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeDatasource carrega o datasource da rota, verificando a permissão do principal sobre ele. Em caso de erro,
+// a resposta já foi escrita.
+func routeDatasource(w http.ResponseWriter, r *http.Request, datasourceRepo contract.IDatasourceRepository, permission authEntity.Permission) (entity.Datasource, bool) {
+	datasource, err := datasourceRepo.GetDatasource(chi.URLParam(r, "id"))
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
+		return entity.Datasource{}, false
+	}
+	if !auth.Can(r.Context(), permission, datasource.ID, datasource.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return entity.Datasource{}, false
+	}
+	return datasource, true
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	auditContract "github.com/bvaledev/database-backup-management-be/internal/domain/audit/contract"
+	auditEntity "github.com/bvaledev/database-backup-management-be/internal/domain/audit/entity"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type TemporaryDatabaseController struct {
+	temporaryDatabaseRepo contract.ITemporaryDatabaseRepository
+	datasourceRepo        contract.IDatasourceRepository
+	cleaner               contract.ITemporaryDatabaseCleaner
+	auditRecorder         auditContract.IRecorder
+}
+
+func NewTemporaryDatabaseController(temporaryDatabaseRepo contract.ITemporaryDatabaseRepository, datasourceRepo contract.IDatasourceRepository, cleaner contract.ITemporaryDatabaseCleaner, auditRecorder auditContract.IRecorder) *TemporaryDatabaseController {
+	return &TemporaryDatabaseController{temporaryDatabaseRepo, datasourceRepo, cleaner, auditRecorder}
+}
+
+// List retorna os bancos temporários cujo datasource de origem o principal pode ler.
+func (c *TemporaryDatabaseController) List(w http.ResponseWriter, r *http.Request) {
+	databases, err := c.temporaryDatabaseRepo.GetTemporaryDatabases()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os bancos temporários")
+		return
+	}
+
+	datasources, err := c.datasourceRepo.GetDatasources(nil)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os bancos temporários")
+		return
+	}
+	readable := make(map[string]bool, len(datasources))
+	for _, datasource := range datasources {
+		readable[datasource.ID] = auth.Can(r.Context(), authEntity.PermBackupRead, datasource.ID, datasource.Tags)
+	}
+
+	visible := make([]entity.TemporaryDatabase, 0, len(databases))
+	for _, database := range databases {
+		if readable[database.DatasourceId] {
+			visible = append(visible, database)
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, visible)
+}
+
+// Delete remove o banco temporário antes do fim do ttl, junto com o datasource temporário, se houver.
+func (c *TemporaryDatabaseController) Delete(w http.ResponseWriter, r *http.Request) {
+	database, err := c.temporaryDatabaseRepo.GetTemporaryDatabase(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "banco temporário não encontrado")
+		return
+	}
+	ds, err := c.datasourceRepo.GetDatasource(database.DatasourceId)
+	if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "banco temporário não encontrado")
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermBackupRestore, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+	if database.DroppedAt != nil {
+		utils.JSONError(w, http.StatusConflict, "o banco temporário já foi removido")
+		return
+	}
+
+	dropped, err := c.cleaner.Drop(database)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.auditRecorder.Record(r.Context(), auditEntity.ActionTemporaryDbDrop, "temporary_database", database.ID, database, dropped)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/application/auth"
+	authEntity "github.com/bvaledev/database-backup-management-be/internal/domain/auth/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+// maxWalFileSize é o maior segmento de WAL aceito (o limite do --wal-segsize do initdb).
+const maxWalFileSize = 1 << 30
+
+type WalArchiveController struct {
+	datasourceRepo contract.IDatasourceRepository
+	walSegmentRepo contract.IWalSegmentRepository
+	walArchive     contract.IWalArchive
+}
+
+func NewWalArchiveController(datasourceRepo contract.IDatasourceRepository, walSegmentRepo contract.IWalSegmentRepository, walArchive contract.IWalArchive) *WalArchiveController {
+	return &WalArchiveController{datasourceRepo, walSegmentRepo, walArchive}
+}
+
+// Archive é o destino do archive_command do servidor de um datasource com backup físico: recebe o arquivo
+// de WAL no corpo da requisição (PUT /v1/datasources/{id}/wal/{file}). Responde 201 ao arquivar, 200 para
+// um reenvio idêntico e 409 se o arquivo já existe com outro conteúdo; qualquer resposta diferente de 2xx
+// faz o PostgreSQL repetir o envio. Os envios não são auditados, pois ocorrem a cada segmento.
+func (c *WalArchiveController) Archive(w http.ResponseWriter, r *http.Request) {
+	ds, err := c.datasourceRepo.GetDatasource(chi.URLParam(r, "id"))
+	if err != nil || !auth.Can(r.Context(), authEntity.PermDatasourceRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
+		return
+	}
+	if !auth.Can(r.Context(), authEntity.PermBackupCreate, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusForbidden, "permissão insuficiente")
+		return
+	}
+	if !ds.BackupFormat.IsPhysical() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "o arquivamento de WAL exige um datasource com backup_format physical")
+		return
+	}
+
+	segment, created, err := c.walArchive.Archive(ds, chi.URLParam(r, "file"), http.MaxBytesReader(w, r.Body, maxWalFileSize))
+	if errors.Is(err, entity.ErrInvalidWalFileName) {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, entity.ErrWalSegmentConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if created {
+		utils.JSONResponse(w, http.StatusCreated, segment)
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, segment)
+}
+
+// List retorna os arquivos de WAL arquivados do datasource, em ordem de arquivamento.
+func (c *WalArchiveController) List(w http.ResponseWriter, r *http.Request) {
+	ds, err := c.datasourceRepo.GetDatasource(chi.URLParam(r, "id"))
+	if err != nil || !auth.Can(r.Context(), authEntity.PermBackupRead, ds.ID, ds.Tags) {
+		utils.JSONError(w, http.StatusNotFound, "datasource não encontrado")
+		return
+	}
+
+	segments, err := c.walSegmentRepo.GetWalSegments(ds.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os arquivos de WAL")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, segments)
+}
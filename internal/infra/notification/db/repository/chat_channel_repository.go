@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+)
+
+type ChatChannelRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IChatChannelRepository = (*ChatChannelRepository)(nil)
+
+func NewChatChannelRepository(db *sql.DB) *ChatChannelRepository {
+	return &ChatChannelRepository{db}
+}
+
+// GetChannel implements IChatChannelRepository.
+func (repo *ChatChannelRepository) GetChannel(entityID string) (entity.ChatChannel, error) {
+	var channel entity.ChatChannel
+
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, kind, webhook_url, severity, enabled, created_at
+		FROM chat_channels
+		WHERE id = $1::uuid
+	`, entityID)
+	err := row.Scan(
+		&channel.ID,
+		&channel.DatasourceId,
+		&channel.Kind,
+		&channel.WebhookURL,
+		&channel.Severity,
+		&channel.Enabled,
+		&channel.CreatedAt,
+	)
+	if err != nil {
+		return entity.ChatChannel{}, err
+	}
+	return channel, nil
+}
+
+// GetChannels implements IChatChannelRepository.
+//
+// Quando datasourceId é informado, retorna os canais do datasource e os canais globais.
+func (repo *ChatChannelRepository) GetChannels(datasourceId *string) ([]entity.ChatChannel, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if datasourceId == nil {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, kind, webhook_url, severity, enabled, created_at
+			FROM chat_channels
+			ORDER BY created_at
+		`)
+	} else {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, kind, webhook_url, severity, enabled, created_at
+			FROM chat_channels
+			WHERE datasource_id IS NULL OR datasource_id = $1::uuid
+			ORDER BY created_at
+		`, *datasourceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels := make([]entity.ChatChannel, 0)
+	for rows.Next() {
+		var channel entity.ChatChannel
+		err := rows.Scan(
+			&channel.ID,
+			&channel.DatasourceId,
+			&channel.Kind,
+			&channel.WebhookURL,
+			&channel.Severity,
+			&channel.Enabled,
+			&channel.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// CreateChannel implements IChatChannelRepository.
+func (repo *ChatChannelRepository) CreateChannel(entity entity.ChatChannel) error {
+	channel, err := entity.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(`
+		INSERT INTO chat_channels (id, datasource_id, kind, webhook_url, severity, enabled, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		channel.ID,
+		channel.DatasourceId,
+		channel.Kind,
+		channel.WebhookURL,
+		channel.Severity,
+		channel.Enabled,
+		channel.CreatedAt,
+	)
+	return err
+}
+
+// UpdateChannel implements IChatChannelRepository.
+func (repo *ChatChannelRepository) UpdateChannel(entity entity.ChatChannel) error {
+	channel, err := entity.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(`
+		UPDATE chat_channels
+		SET datasource_id = $2, kind = $3, webhook_url = $4, severity = $5, enabled = $6
+		WHERE id = $1::uuid
+	`,
+		channel.ID,
+		channel.DatasourceId,
+		channel.Kind,
+		channel.WebhookURL,
+		channel.Severity,
+		channel.Enabled,
+	)
+	return err
+}
+
+// DeleteChannel implements IChatChannelRepository.
+func (repo *ChatChannelRepository) DeleteChannel(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM chat_channels
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+)
+
+type EmailRecipientRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IEmailRecipientRepository = (*EmailRecipientRepository)(nil)
+
+func NewEmailRecipientRepository(db *sql.DB) *EmailRecipientRepository {
+	return &EmailRecipientRepository{db}
+}
+
+// GetRecipient implements IEmailRecipientRepository.
+func (repo *EmailRecipientRepository) GetRecipient(entityID string) (entity.EmailRecipient, error) {
+	var recipient entity.EmailRecipient
+
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, email, alerts, digest, created_at
+		FROM email_recipients
+		WHERE id = $1::uuid
+	`, entityID)
+	err := row.Scan(
+		&recipient.ID,
+		&recipient.DatasourceId,
+		&recipient.Email,
+		&recipient.Alerts,
+		&recipient.Digest,
+		&recipient.CreatedAt,
+	)
+	if err != nil {
+		return entity.EmailRecipient{}, err
+	}
+	return recipient, nil
+}
+
+// GetRecipients implements IEmailRecipientRepository.
+//
+// Quando datasourceId é informado, retorna os destinatários do datasource e os destinatários globais.
+func (repo *EmailRecipientRepository) GetRecipients(datasourceId *string) ([]entity.EmailRecipient, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if datasourceId == nil {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, email, alerts, digest, created_at
+			FROM email_recipients
+			ORDER BY email
+		`)
+	} else {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, email, alerts, digest, created_at
+			FROM email_recipients
+			WHERE datasource_id IS NULL OR datasource_id = $1::uuid
+			ORDER BY email
+		`, *datasourceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipients := make([]entity.EmailRecipient, 0)
+	for rows.Next() {
+		var recipient entity.EmailRecipient
+		err := rows.Scan(
+			&recipient.ID,
+			&recipient.DatasourceId,
+			&recipient.Email,
+			&recipient.Alerts,
+			&recipient.Digest,
+			&recipient.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// CreateRecipient implements IEmailRecipientRepository.
+func (repo *EmailRecipientRepository) CreateRecipient(entity entity.EmailRecipient) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO email_recipients (id, datasource_id, email, alerts, digest, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.Email,
+		entity.Alerts,
+		entity.Digest,
+		entity.CreatedAt,
+	)
+	return err
+}
+
+// UpdateRecipient implements IEmailRecipientRepository.
+func (repo *EmailRecipientRepository) UpdateRecipient(entity entity.EmailRecipient) error {
+	_, err := repo.db.Exec(`
+		UPDATE email_recipients
+		SET datasource_id = $2, email = $3, alerts = $4, digest = $5
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.DatasourceId,
+		entity.Email,
+		entity.Alerts,
+		entity.Digest,
+	)
+	return err
+}
+
+// DeleteRecipient implements IEmailRecipientRepository.
+func (repo *EmailRecipientRepository) DeleteRecipient(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM email_recipients
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+)
+
+type WebhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IWebhookDeliveryRepository = (*WebhookDeliveryRepository)(nil)
+
+func NewWebhookDeliveryRepository(db *sql.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db}
+}
+
+// GetDeliveries implements IWebhookDeliveryRepository.
+func (repo *WebhookDeliveryRepository) GetDeliveries(webhookId string) ([]entity.WebhookDelivery, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, error, created_at, last_attempt_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1::uuid
+		ORDER BY created_at DESC
+		LIMIT 100
+	`, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookId,
+			&delivery.EventId,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.Error,
+			&delivery.CreatedAt,
+			&delivery.LastAttemptAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CreateDelivery implements IWebhookDeliveryRepository.
+func (repo *WebhookDeliveryRepository) CreateDelivery(entity entity.WebhookDelivery) error {
+	_, err := repo.db.Exec(`
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, response_status, error, created_at, last_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		entity.ID,
+		entity.WebhookId,
+		entity.EventId,
+		entity.EventType,
+		entity.Payload,
+		entity.Status,
+		entity.Attempts,
+		entity.ResponseStatus,
+		entity.Error,
+		entity.CreatedAt,
+		entity.LastAttemptAt,
+	)
+	return err
+}
+
+// UpdateDelivery implements IWebhookDeliveryRepository.
+func (repo *WebhookDeliveryRepository) UpdateDelivery(entity entity.WebhookDelivery) error {
+	_, err := repo.db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, error = $5, last_attempt_at = $6
+		WHERE id = $1::uuid
+	`,
+		entity.ID,
+		entity.Status,
+		entity.Attempts,
+		entity.ResponseStatus,
+		entity.Error,
+		entity.LastAttemptAt,
+	)
+	return err
+}
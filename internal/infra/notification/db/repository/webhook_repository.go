@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+var _ contract.IWebhookRepository = (*WebhookRepository)(nil)
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+// GetWebhook implements IWebhookRepository.
+func (repo *WebhookRepository) GetWebhook(entityID string) (entity.Webhook, error) {
+	row := repo.db.QueryRow(`
+		SELECT id, datasource_id, url, secret, events, enabled, created_at
+		FROM webhooks
+		WHERE id = $1::uuid
+	`, entityID)
+
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		return entity.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// GetWebhooks implements IWebhookRepository.
+//
+// Quando datasourceId é informado, retorna os webhooks do datasource e os webhooks globais.
+func (repo *WebhookRepository) GetWebhooks(datasourceId *string) ([]entity.Webhook, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if datasourceId == nil {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, url, secret, events, enabled, created_at
+			FROM webhooks
+			ORDER BY created_at
+		`)
+	} else {
+		rows, err = repo.db.Query(`
+			SELECT id, datasource_id, url, secret, events, enabled, created_at
+			FROM webhooks
+			WHERE datasource_id IS NULL OR datasource_id = $1::uuid
+			ORDER BY created_at
+		`, *datasourceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook implements IWebhookRepository.
+func (repo *WebhookRepository) CreateWebhook(entity entity.Webhook) error {
+	webhook, err := entity.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(`
+		INSERT INTO webhooks (id, datasource_id, url, secret, events, enabled, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		webhook.ID,
+		webhook.DatasourceId,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventTypesToStrings(webhook.Events)),
+		webhook.Enabled,
+		webhook.CreatedAt,
+	)
+	return err
+}
+
+// UpdateWebhook implements IWebhookRepository.
+func (repo *WebhookRepository) UpdateWebhook(entity entity.Webhook) error {
+	webhook, err := entity.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(`
+		UPDATE webhooks
+		SET datasource_id = $2, url = $3, secret = $4, events = $5, enabled = $6
+		WHERE id = $1::uuid
+	`,
+		webhook.ID,
+		webhook.DatasourceId,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventTypesToStrings(webhook.Events)),
+		webhook.Enabled,
+	)
+	return err
+}
+
+// DeleteWebhook implements IWebhookRepository.
+func (repo *WebhookRepository) DeleteWebhook(entityID string) error {
+	result, err := repo.db.Exec(`
+		DELETE FROM webhooks
+		WHERE id = $1::uuid
+	`, entityID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row rowScanner) (entity.Webhook, error) {
+	var (
+		webhook entity.Webhook
+		events  []string
+	)
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.DatasourceId,
+		&webhook.URL,
+		&webhook.Secret,
+		pq.Array(&events),
+		&webhook.Enabled,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return entity.Webhook{}, err
+	}
+	webhook.Events = make([]entity.EventType, 0, len(events))
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, entity.EventType(event))
+	}
+	return webhook, nil
+}
+
+func eventTypesToStrings(events []entity.EventType) []string {
+	values := make([]string, 0, len(events))
+	for _, event := range events {
+		values = append(values, string(event))
+	}
+	return values
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type ChatChannelController struct {
+	channelRepo contract.IChatChannelRepository
+	tester      contract.IChatTester
+}
+
+func NewChatChannelController(channelRepo contract.IChatChannelRepository, tester contract.IChatTester) *ChatChannelController {
+	return &ChatChannelController{channelRepo, tester}
+}
+
+func (c *ChatChannelController) List(w http.ResponseWriter, r *http.Request) {
+	datasourceId := r.URL.Query().Get("datasourceId")
+	var (
+		channels []entity.ChatChannel
+		err      error
+	)
+
+	if datasourceId == "" {
+		channels, err = c.channelRepo.GetChannels(nil)
+	} else {
+		channels, err = c.channelRepo.GetChannels(&datasourceId)
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os canais")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, channels)
+}
+
+func (c *ChatChannelController) Get(w http.ResponseWriter, r *http.Request) {
+	channelId := chi.URLParam(r, "id")
+	channel, err := c.channelRepo.GetChannel(channelId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "canal não encontrado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, channel)
+}
+
+func (c *ChatChannelController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateChatChannelDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	channel, err := entity.NewChatChannel(input.DatasourceId, entity.ChatKind(input.Kind), input.WebhookURL, entity.ChatSeverity(input.Severity), input.Enabled)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.channelRepo.CreateChannel(*channel); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o canal")
+		return
+	}
+	response := map[string]string{
+		"id": channel.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *ChatChannelController) Update(w http.ResponseWriter, r *http.Request) {
+	channelId := chi.URLParam(r, "id")
+	var input dto.UpdateChatChannelDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	channel, err := c.channelRepo.GetChannel(channelId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o canal não existe")
+		return
+	}
+
+	channel.DatasourceId = input.DatasourceId
+	channel.Kind = entity.ChatKind(input.Kind)
+	channel.Severity = entity.ChatSeverity(input.Severity)
+	channel.Enabled = input.Enabled
+	if input.WebhookURL != "" {
+		channel.WebhookURL = input.WebhookURL
+	}
+	if err := channel.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.channelRepo.UpdateChannel(channel); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o canal")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ChatChannelController) Delete(w http.ResponseWriter, r *http.Request) {
+	channelId := chi.URLParam(r, "id")
+	if err := c.channelRepo.DeleteChannel(channelId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o canal não existe")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ChatChannelController) Test(w http.ResponseWriter, r *http.Request) {
+	channelId := chi.URLParam(r, "id")
+	channel, err := c.channelRepo.GetChannel(channelId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "canal não encontrado")
+		return
+	}
+
+	if err := c.tester.SendTest(channel); err != nil {
+		utils.JSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	response := map[string]string{
+		"message": "mensagem de teste enviada",
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type EmailRecipientController struct {
+	recipientRepo contract.IEmailRecipientRepository
+	digestSender  contract.IDigestSender
+}
+
+func NewEmailRecipientController(recipientRepo contract.IEmailRecipientRepository, digestSender contract.IDigestSender) *EmailRecipientController {
+	return &EmailRecipientController{recipientRepo, digestSender}
+}
+
+func (c *EmailRecipientController) List(w http.ResponseWriter, r *http.Request) {
+	datasourceId := r.URL.Query().Get("datasourceId")
+	var (
+		recipients []entity.EmailRecipient
+		err        error
+	)
+
+	if datasourceId == "" {
+		recipients, err = c.recipientRepo.GetRecipients(nil)
+	} else {
+		recipients, err = c.recipientRepo.GetRecipients(&datasourceId)
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os destinatários")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, recipients)
+}
+
+func (c *EmailRecipientController) Get(w http.ResponseWriter, r *http.Request) {
+	recipientId := chi.URLParam(r, "id")
+	recipient, err := c.recipientRepo.GetRecipient(recipientId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "destinatário não encontrado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, recipient)
+}
+
+func (c *EmailRecipientController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateEmailRecipientDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	recipient, err := entity.NewEmailRecipient(input.DatasourceId, input.Email, input.Alerts, input.Digest)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.recipientRepo.CreateRecipient(*recipient); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o destinatário")
+		return
+	}
+	response := map[string]string{
+		"id": recipient.ID,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *EmailRecipientController) Update(w http.ResponseWriter, r *http.Request) {
+	recipientId := chi.URLParam(r, "id")
+	var input dto.UpdateEmailRecipientDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	recipient, err := c.recipientRepo.GetRecipient(recipientId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o destinatário não existe")
+		return
+	}
+
+	recipient.DatasourceId = input.DatasourceId
+	recipient.Email = input.Email
+	recipient.Alerts = input.Alerts
+	recipient.Digest = input.Digest
+	if err := recipient.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.recipientRepo.UpdateRecipient(recipient); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o destinatário")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *EmailRecipientController) Delete(w http.ResponseWriter, r *http.Request) {
+	recipientId := chi.URLParam(r, "id")
+	if err := c.recipientRepo.DeleteRecipient(recipientId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o destinatário não existe")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendDigest dispara o envio do resumo de backups imediatamente.
+func (c *EmailRecipientController) SendDigest(w http.ResponseWriter, r *http.Request) {
+	if c.digestSender == nil {
+		utils.JSONError(w, http.StatusServiceUnavailable, "envio de e-mails não configurado")
+		return
+	}
+	if err := c.digestSender.SendDigest(); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível enviar o resumo")
+		return
+	}
+
+	response := map[string]string{
+		"message": "resumo enviado",
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	backupEntity "github.com/bvaledev/database-backup-management-be/internal/domain/backup/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/dto"
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/entity"
+	"github.com/bvaledev/database-backup-management-be/internal/utils"
+	"github.com/go-chi/chi"
+)
+
+type WebhookController struct {
+	webhookRepo  contract.IWebhookRepository
+	deliveryRepo contract.IWebhookDeliveryRepository
+	sender       contract.IWebhookSender
+}
+
+func NewWebhookController(webhookRepo contract.IWebhookRepository, deliveryRepo contract.IWebhookDeliveryRepository, sender contract.IWebhookSender) *WebhookController {
+	return &WebhookController{webhookRepo, deliveryRepo, sender}
+}
+
+func (c *WebhookController) List(w http.ResponseWriter, r *http.Request) {
+	datasourceId := r.URL.Query().Get("datasourceId")
+	var (
+		webhooks []entity.Webhook
+		err      error
+	)
+
+	if datasourceId == "" {
+		webhooks, err = c.webhookRepo.GetWebhooks(nil)
+	} else {
+		webhooks, err = c.webhookRepo.GetWebhooks(&datasourceId)
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar os webhooks")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, webhooks)
+}
+
+func (c *WebhookController) Get(w http.ResponseWriter, r *http.Request) {
+	webhookId := chi.URLParam(r, "id")
+	webhook, err := c.webhookRepo.GetWebhook(webhookId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "webhook não encontrado")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, webhook)
+}
+
+func (c *WebhookController) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateWebhookDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "json inválido")
+		return
+	}
+
+	webhook, err := entity.NewWebhook(input.DatasourceId, input.URL, input.Secret, toEventTypes(input.Events), input.Enabled)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := c.webhookRepo.CreateWebhook(*webhook); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível cadastrar o webhook")
+		return
+	}
+
+	// O segredo só é exibido na criação, para que o receptor possa validar as assinaturas.
+	response := map[string]string{
+		"id":     webhook.ID,
+		"secret": webhook.Secret,
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, response)
+}
+
+func (c *WebhookController) Update(w http.ResponseWriter, r *http.Request) {
+	webhookId := chi.URLParam(r, "id")
+	var input dto.UpdateWebhookDto
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "json inválido")
+		return
+	}
+	webhook, err := c.webhookRepo.GetWebhook(webhookId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o webhook não existe")
+		return
+	}
+
+	webhook.DatasourceId = input.DatasourceId
+	webhook.URL = input.URL
+	webhook.Events = toEventTypes(input.Events)
+	webhook.Enabled = input.Enabled
+	if input.Secret != "" {
+		webhook.Secret = input.Secret
+	}
+	if err := webhook.Validate(); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err := c.webhookRepo.UpdateWebhook(webhook); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, "não foi possível atualizar o webhook")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *WebhookController) Delete(w http.ResponseWriter, r *http.Request) {
+	webhookId := chi.URLParam(r, "id")
+	if err := c.webhookRepo.DeleteWebhook(webhookId); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "o webhook não existe")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Test envia um evento de teste ao webhook de forma síncrona, com uma única tentativa.
+func (c *WebhookController) Test(w http.ResponseWriter, r *http.Request) {
+	webhookId := chi.URLParam(r, "id")
+	webhook, err := c.webhookRepo.GetWebhook(webhookId)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "webhook não encontrado")
+		return
+	}
+
+	ds := backupEntity.Datasource{}
+	if webhook.DatasourceId != nil {
+		ds.ID = *webhook.DatasourceId
+	}
+	event := entity.NewEvent(entity.EventTest, ds, nil)
+
+	delivery, err := c.sender.Deliver(webhook, event, 1)
+	if err != nil && delivery.ID == "" {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível enviar o evento de teste")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, delivery)
+}
+
+func (c *WebhookController) Deliveries(w http.ResponseWriter, r *http.Request) {
+	webhookId := chi.URLParam(r, "id")
+	deliveries, err := c.deliveryRepo.GetDeliveries(webhookId)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "não foi possível retornar as entregas")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, deliveries)
+}
+
+func toEventTypes(events []string) []entity.EventType {
+	eventTypes := make([]entity.EventType, 0, len(events))
+	for _, event := range events {
+		eventTypes = append(eventTypes, entity.EventType(event))
+	}
+	return eventTypes
+}
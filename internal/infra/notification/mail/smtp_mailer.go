@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bvaledev/database-backup-management-be/internal/domain/notification/contract"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPConfigFromEnv carrega a configuração SMTP das variáveis SMTP_HOST, SMTP_PORT,
+// SMTP_USERNAME, SMTP_PASSWORD e SMTP_FROM.
+//
+// Retorna false quando SMTP_HOST não está definido, indicando que o envio de e-mails está desabilitado.
+func SMTPConfigFromEnv() (SMTPConfig, bool) {
+	config := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if config.Host == "" {
+		return SMTPConfig{}, false
+	}
+	if config.Port == "" {
+		config.Port = "25"
+	}
+	if config.From == "" {
+		config.From = "dbbm@localhost"
+	}
+	return config, true
+}
+
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+var _ contract.IMailer = (*SMTPMailer)(nil)
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config}
+}
+
+// Send envia uma mensagem em texto simples via SMTP.
+//
+// A autenticação PLAIN só é utilizada quando SMTP_USERNAME está definido, permitindo
+// o uso de servidores SMTP locais de teste (ex: Mailpit, MailHog) sem credenciais.
+// O STARTTLS é negociado automaticamente quando o servidor o anuncia.
+func (m *SMTPMailer) Send(to []string, subject, body string) error {
+	if len(to) == 0 {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	addr := fmt.Sprintf("%s:%s", m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, to, msg.Bytes()); err != nil {
+		return fmt.Errorf("erro ao enviar e-mail: %w", err)
+	}
+	return nil
+}
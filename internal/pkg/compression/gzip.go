@@ -8,6 +8,12 @@ import (
 )
 
 func CompressToGzip(source, target string) error {
+	return CompressToGzipLevel(source, target, gzip.DefaultCompression)
+}
+
+// CompressToGzipLevel compacta source em target com o nível do gzip informado (gzip.BestSpeed a gzip.BestCompression,
+// ou gzip.DefaultCompression) e remove source.
+func CompressToGzipLevel(source, target string, level int) error {
 	in, err := os.Open(source)
 	if err != nil {
 		return err
@@ -20,7 +26,10 @@ func CompressToGzip(source, target string) error {
 	}
 	defer out.Close()
 
-	gw := gzip.NewWriter(out)
+	gw, err := gzip.NewWriterLevel(out, level)
+	if err != nil {
+		return err
+	}
 	defer gw.Close()
 
 	_, err = io.Copy(gw, in)
@@ -0,0 +1,142 @@
+package compression
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveDirectory empacota o conteúdo de um diretório em um arquivo tar e remove o diretório.
+func ArchiveDirectory(source, target string) error {
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	tw := tar.NewWriter(out)
+	err = filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == source {
+			return nil
+		}
+		name, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		_, err = io.Copy(tw, in)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(source)
+}
+
+// ExtractArchive extrai um arquivo tar para um novo diretório ao lado dele, nomeado a partir do arquivo
+// sem a extensão .tar. Apenas arquivos regulares e diretórios são extraídos, e caminhos fora do destino
+// são rejeitados.
+func ExtractArchive(tarFile string) (string, error) {
+	f, err := os.Open(tarFile)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	outputDir, err := os.MkdirTemp(filepath.Dir(tarFile), strings.TrimSuffix(filepath.Base(tarFile), ".tar")+"-")
+	if err != nil {
+		return "", err
+	}
+
+	if err := extractTar(tar.NewReader(f), outputDir); err != nil {
+		os.RemoveAll(outputDir)
+		return "", err
+	}
+	return outputDir, nil
+}
+
+// ExtractTarGz extrai um arquivo .tar.gz para um diretório existente, preservando as permissões dos
+// arquivos. Assim como em ExtractArchive, apenas arquivos regulares e diretórios são extraídos.
+func ExtractTarGz(tarGzFile, outputDir string) error {
+	f, err := os.Open(tarGzFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return extractTar(tar.NewReader(gz), outputDir)
+}
+
+func extractTar(tr *tar.Reader, outputDir string) error {
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("caminho inválido no arquivo: %s", header.Name)
+		}
+		target := filepath.Join(outputDir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, header.FileInfo().Mode().Perm()|0700)
+		case tar.TypeReg:
+			err = extractFile(target, tr, header.FileInfo().Mode().Perm())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(target string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, r)
+	return err
+}
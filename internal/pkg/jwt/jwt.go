@@ -0,0 +1,230 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed        = errors.New("token malformado")
+	ErrUnknownKey       = errors.New("chave de assinatura desconhecida")
+	ErrInvalidSignature = errors.New("assinatura inválida")
+	ErrExpired          = errors.New("token expirado")
+	ErrNotYetValid      = errors.New("token ainda não é válido")
+)
+
+// leeway tolera pequenas diferenças de relógio entre o emissor e este servidor.
+var leeway = time.Minute
+
+type Header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Claims contém as claims do token. Valores não padronizados ficam disponíveis em Raw.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	Raw       map[string]any
+}
+
+// LooksLikeJWT indica se a credencial tem o formato de um JWS compacto (três partes separadas por ponto).
+func LooksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// ParseHeader decodifica o cabeçalho do token sem validar a assinatura.
+func ParseHeader(token string) (Header, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Header{}, ErrMalformed
+	}
+	var header Header
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Header{}, ErrMalformed
+	}
+	return header, nil
+}
+
+// Verify valida a assinatura do token com as chaves informadas e as claims temporais (exp e nbf).
+//
+// Algoritmos suportados: RS256, RS384, RS512, ES256, ES384 e ES512.
+// A validação de emissor e audiência fica a cargo de quem chama.
+func Verify(token string, keys KeySet) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+
+	header, err := ParseHeader(token)
+	if err != nil {
+		return Claims{}, err
+	}
+	key, ok := keys[header.Kid]
+	if !ok {
+		return Claims{}, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return Claims{}, err
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	claims := Claims{
+		Issuer:    stringClaim(raw, "iss"),
+		Subject:   stringClaim(raw, "sub"),
+		Audience:  StringsClaim(raw, "aud"),
+		ExpiresAt: timeClaim(raw, "exp"),
+		NotBefore: timeClaim(raw, "nbf"),
+		Raw:       raw,
+	}
+
+	now := time.Now()
+	if claims.ExpiresAt.IsZero() || now.After(claims.ExpiresAt.Add(leeway)) {
+		return Claims{}, ErrExpired
+	}
+	if !claims.NotBefore.IsZero() && now.Add(leeway).Before(claims.NotBefore) {
+		return Claims{}, ErrNotYetValid
+	}
+	return claims, nil
+}
+
+// String retorna uma claim textual, ou vazio se ausente.
+func (c Claims) String(name string) string {
+	return stringClaim(c.Raw, name)
+}
+
+// Bool indica se uma claim booleana está presente e verdadeira. Aceita também a string "true", usada por alguns
+// provedores (ex: "email_verified" no Cognito).
+func (c Claims) Bool(name string) bool {
+	switch v := c.Raw[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// HasAudience indica se a audiência informada consta no token.
+func (c Claims) HasAudience(audience string) bool {
+	for _, aud := range c.Audience {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// StringsClaim retorna uma claim que pode ser uma string ou uma lista de strings.
+// Nomes com pontos (ex: "realm_access.roles") navegam em objetos aninhados.
+func StringsClaim(raw map[string]any, name string) []string {
+	var value any = raw
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("algoritmo não suportado: %s", alg)
+	}
+	hasher := hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch strings.ToUpper(alg[:2]) {
+	case "RS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature); err != nil {
+			return ErrInvalidSignature
+		}
+	case "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func stringClaim(raw map[string]any, name string) string {
+	value, _ := raw[name].(string)
+	return value
+}
+
+func timeClaim(raw map[string]any, name string) time.Time {
+	value, ok := raw[name].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(value), 0)
+}
@@ -0,0 +1,281 @@
+package pgdump
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrTableNotFound indica que o script não contém um COPY com os dados da tabela. Backups gerados com
+// `pg_dump --inserts` não usam COPY e não são suportados.
+var ErrTableNotFound = errors.New("dados da tabela não encontrados no backup (backups gerados com --inserts não são suportados)")
+
+// copyStatement captura a tabela e as colunas de "COPY schema.tabela (col1, col2) FROM stdin;".
+var copyStatement = regexp.MustCompile(`^COPY\s+(.+?)\s*(?:\((.*)\))?\s+FROM stdin;$`)
+
+// TableReader lê em fluxo as linhas do COPY de uma tabela em um script SQL.
+type TableReader struct {
+	reader  *bufio.Reader
+	closer  io.Closer
+	schema  string
+	table   string
+	columns []string
+	done    bool
+}
+
+// NewTableReader avança o script até o COPY da tabela (schema vazio aceita qualquer schema).
+// closer, se informado, é chamado em Close para liberar o script (processo, arquivo etc.).
+func NewTableReader(r io.Reader, closer io.Closer, schema, table string) (*TableReader, error) {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for {
+		line, err := readLine(reader)
+		if errors.Is(err, io.EOF) {
+			return nil, ErrTableNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "COPY ") {
+			continue
+		}
+		match := copyStatement.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		copySchema, copyTable := splitQuoted(match[1])
+		if copyTable != table || (schema != "" && copySchema != schema) {
+			continue
+		}
+		return &TableReader{
+			reader:  reader,
+			closer:  closer,
+			schema:  copySchema,
+			table:   copyTable,
+			columns: splitColumns(match[2]),
+		}, nil
+	}
+}
+
+// Schema retorna o schema da tabela encontrada.
+func (t *TableReader) Schema() string {
+	return t.schema
+}
+
+// Table retorna o nome da tabela encontrada.
+func (t *TableReader) Table() string {
+	return t.table
+}
+
+// Columns retorna as colunas na ordem do COPY.
+func (t *TableReader) Columns() []string {
+	return t.columns
+}
+
+// Next retorna a próxima linha, com nil para valores NULL, ou io.EOF ao fim dos dados.
+func (t *TableReader) Next() ([]*string, error) {
+	if t.done {
+		return nil, io.EOF
+	}
+	line, err := readLine(t.reader)
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("dados da tabela %s.%s incompletos no backup", t.schema, t.table)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if line == `\.` {
+		t.done = true
+		return nil, io.EOF
+	}
+	return parseCopyLine(line), nil
+}
+
+func (t *TableReader) Close() error {
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer.Close()
+}
+
+// WriteCSV escreve os dados em CSV, com cabeçalho. Valores NULL viram campos vazios.
+func (t *TableReader) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.columns); err != nil {
+		return err
+	}
+	record := make([]string, len(t.columns))
+	for {
+		row, err := t.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for i := range record {
+			record[i] = ""
+			if i < len(row) && row[i] != nil {
+				record[i] = *row[i]
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteNDJSON escreve um objeto JSON por linha, com as colunas como chaves, na ordem do COPY. Os valores
+// são mantidos como texto (o COPY não carrega o tipo das colunas), e NULL vira null.
+func (t *TableReader) WriteNDJSON(w io.Writer) error {
+	keys := make([][]byte, len(t.columns))
+	for i, column := range t.columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		keys[i] = key
+	}
+
+	writer := bufio.NewWriter(w)
+	for {
+		row, err := t.Next()
+		if errors.Is(err, io.EOF) {
+			return writer.Flush()
+		}
+		if err != nil {
+			return err
+		}
+		writer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				writer.WriteByte(',')
+			}
+			writer.Write(key)
+			writer.WriteByte(':')
+			var value *string
+			if i < len(row) {
+				value = row[i]
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			writer.Write(encoded)
+		}
+		if _, err := writer.WriteString("}\n"); err != nil {
+			return err
+		}
+	}
+}
+
+// readLine lê uma linha completa, sem o "\n" final.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// splitColumns separa a lista de colunas do COPY, removendo as aspas dos identificadores.
+func splitColumns(list string) []string {
+	columns := make([]string, 0)
+	if strings.TrimSpace(list) == "" {
+		return columns
+	}
+	var builder strings.Builder
+	quoted := false
+	for i := 0; i < len(list); i++ {
+		switch c := list[i]; {
+		case c == '"' && quoted && i+1 < len(list) && list[i+1] == '"':
+			builder.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			columns = append(columns, strings.TrimSpace(builder.String()))
+			builder.Reset()
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return append(columns, strings.TrimSpace(builder.String()))
+}
+
+// parseCopyLine decodifica uma linha do COPY em formato texto: campos separados por tab,
+// \N para NULL e sequências de escape com barra invertida.
+func parseCopyLine(line string) []*string {
+	fields := strings.Split(line, "\t")
+	values := make([]*string, len(fields))
+	for i, field := range fields {
+		if field == `\N` {
+			continue
+		}
+		value := unescapeCopy(field)
+		values[i] = &value
+	}
+	return values
+}
+
+func unescapeCopy(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+	var builder strings.Builder
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		if c != '\\' || i+1 >= len(field) {
+			builder.WriteByte(c)
+			continue
+		}
+		i++
+		switch next := field[i]; next {
+		case 'b':
+			builder.WriteByte('\b')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'v':
+			builder.WriteByte('\v')
+		case 'x':
+			end := i + 1
+			for end < len(field) && end < i+3 && isHex(field[end]) {
+				end++
+			}
+			if value, err := strconv.ParseUint(field[i+1:end], 16, 8); err == nil {
+				builder.WriteByte(byte(value))
+				i = end - 1
+			} else {
+				builder.WriteByte(next)
+			}
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			end := i
+			for end < len(field) && end < i+3 && field[end] >= '0' && field[end] <= '7' {
+				end++
+			}
+			value, _ := strconv.ParseUint(field[i:end], 8, 8)
+			builder.WriteByte(byte(value))
+			i = end - 1
+		default:
+			builder.WriteByte(next)
+		}
+	}
+	return builder.String()
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,142 @@
+package pgdump
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	tocEntryComment = regexp.MustCompile(`^-- TOC entry (\d+) `)
+	// tableReference captura tabelas referenciadas em CREATE INDEX ... ON e em FOREIGN KEY ... REFERENCES.
+	tableReference = regexp.MustCompile(`(?:\sON(?:\s+ONLY)?|\sREFERENCES)\s+((?:"(?:[^"]|"")+"|[\w$]+)\.(?:"(?:[^"]|"")+"|[\w$]+))`)
+)
+
+// SplitScript separa o script gerado por `pg_restore -f -` (com comentários) pelo id de cada entrada da TOC.
+func SplitScript(r io.Reader) (map[int]string, error) {
+	statements := make(map[int]string)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	current := -1
+	var builder strings.Builder
+	flush := func() {
+		if current >= 0 {
+			statements[current] = builder.String()
+		}
+		builder.Reset()
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := tocEntryComment.FindStringSubmatch(line); match != nil {
+			flush()
+			current, _ = strconv.Atoi(match[1])
+			continue
+		}
+		builder.WriteString(line)
+		builder.WriteByte('\n')
+	}
+	flush()
+	return statements, scanner.Err()
+}
+
+// ReferencedTables retorna as tabelas ("schema.tabela", sem aspas) referenciadas por um CREATE INDEX
+// ou por uma FOREIGN KEY.
+func ReferencedTables(statement string) []string {
+	tables := make([]string, 0)
+	for _, match := range tableReference.FindAllStringSubmatch(statement, -1) {
+		schema, table := splitQuoted(match[1])
+		tables = append(tables, schema+"."+table)
+	}
+	return tables
+}
+
+// splitQuoted separa um nome qualificado, removendo as aspas dos identificadores.
+func splitQuoted(name string) (string, string) {
+	parts := make([]string, 0, 2)
+	var builder strings.Builder
+	quoted := false
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c == '"' && quoted && i+1 < len(name) && name[i+1] == '"':
+			builder.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			parts = append(parts, builder.String())
+			builder.Reset()
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	parts = append(parts, builder.String())
+	if len(parts) < 2 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// objectComment é o comentário que o pg_dump e o pg_restore escrevem antes de cada objeto no script, ex:
+// "-- Name: users; Type: TABLE; Schema: public; Owner: postgres" ou "-- Data for Name: users; Type: TABLE DATA; ...".
+var objectComment = regexp.MustCompile(`^-- (?:Data for )?Name: (.*); Type: (.*); Schema: (.*); Owner: ([^;]*)`)
+
+// Object é um objeto descrito nos comentários de um script SQL gerado pelo pg_dump ou pelo pg_restore.
+type Object struct {
+	Type   string
+	Schema string
+	Name   string
+	Owner  string
+	// Size é o tamanho em bytes dos comandos (ou dos dados, em TABLE DATA) do objeto no script.
+	Size int64
+}
+
+// ParseObjects percorre um script SQL (formato plain) e retorna os objetos na ordem em que aparecem.
+// O script é lido em fluxo, sem carregar linhas longas (ex: dados de COPY) inteiras na memória.
+func ParseObjects(r io.Reader) ([]Object, error) {
+	objects := make([]Object, 0)
+	reader := bufio.NewReaderSize(r, 64*1024)
+	lineStart := true
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if len(chunk) > 0 {
+			if match := matchObjectComment(chunk, lineStart); match != nil {
+				objects = append(objects, Object{
+					Type:   match[2],
+					Schema: noneToEmpty(match[3]),
+					Name:   match[1],
+					Owner:  noneToEmpty(match[4]),
+				})
+			} else if len(objects) > 0 {
+				objects[len(objects)-1].Size += int64(len(chunk))
+			}
+		}
+		switch {
+		case err == bufio.ErrBufferFull:
+			lineStart = false
+		case err == io.EOF:
+			return objects, nil
+		case err != nil:
+			return nil, err
+		default:
+			lineStart = true
+		}
+	}
+}
+
+func matchObjectComment(chunk []byte, lineStart bool) []string {
+	if !lineStart || !strings.HasPrefix(string(chunk[:min(len(chunk), 3)]), "-- ") {
+		return nil
+	}
+	return objectComment.FindStringSubmatch(strings.TrimRight(string(chunk), "\r\n"))
+}
+
+// noneToEmpty converte o "-" usado pelo pg_dump para campos ausentes em "".
+func noneToEmpty(value string) string {
+	if value == "-" {
+		return ""
+	}
+	return value
+}
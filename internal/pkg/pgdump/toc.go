@@ -0,0 +1,252 @@
+// Package pgdump lê a tabela de conteúdo (TOC) de backups do pg_dump no formato custom,
+// obtida com `pg_restore --list`, e monta as listas usadas em `pg_restore -L`.
+package pgdump
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tipos de entrada usados na seleção de objetos.
+const (
+	TypeSchema       = "SCHEMA"
+	TypeTable        = "TABLE"
+	TypeTableData    = "TABLE DATA"
+	TypeIndex        = "INDEX"
+	TypeFKConstraint = "FK CONSTRAINT"
+)
+
+// entryTypes são as descrições de objetos emitidas pelo pg_restore --list. As descrições com mais de
+// uma palavra precisam ser reconhecidas antes do schema e do nome, por isso ficam ordenadas da mais longa
+// para a mais curta.
+var entryTypes = []string{
+	"MATERIALIZED VIEW DATA",
+	"TEXT SEARCH CONFIGURATION",
+	"TEXT SEARCH DICTIONARY",
+	"TEXT SEARCH TEMPLATE",
+	"TEXT SEARCH PARSER",
+	"SEQUENCE OWNED BY",
+	"MATERIALIZED VIEW",
+	"FOREIGN DATA WRAPPER",
+	"PUBLICATION TABLE",
+	"CHECK CONSTRAINT",
+	"OPERATOR FAMILY",
+	"OPERATOR CLASS",
+	"EVENT TRIGGER",
+	"FOREIGN TABLE",
+	"FK CONSTRAINT",
+	"LARGE OBJECT",
+	"ROW SECURITY",
+	"SEQUENCE SET",
+	"USER MAPPING",
+	"DEFAULT ACL",
+	"INDEX ATTACH",
+	"TABLE ATTACH",
+	"TABLE DATA",
+	"ACCESS METHOD",
+	"BLOB DATA",
+	"BLOBS",
+	"BLOB",
+	"AGGREGATE",
+	"CAST",
+	"COLLATION",
+	"COMMENT",
+	"CONSTRAINT",
+	"CONVERSION",
+	"DATABASE",
+	"DEFAULT",
+	"DOMAIN",
+	"ENCODING",
+	"EXTENSION",
+	"FUNCTION",
+	"INDEX",
+	"LANGUAGE",
+	"OPERATOR",
+	"POLICY",
+	"PROCEDURE",
+	"PUBLICATION",
+	"RULE",
+	"SCHEMA",
+	"SEARCHPATH",
+	"SECURITY LABEL",
+	"SEQUENCE",
+	"SERVER",
+	"STATISTICS",
+	"STDSTRINGS",
+	"SUBSCRIPTION",
+	"TABLE",
+	"TRANSFORM",
+	"TRIGGER",
+	"TYPE",
+	"ACL",
+	"VIEW",
+}
+
+func init() {
+	sort.SliceStable(entryTypes, func(i, j int) bool {
+		return len(entryTypes[i]) > len(entryTypes[j])
+	})
+}
+
+// Entry é uma linha da TOC, no formato "<id>; <tableoid> <oid> <tipo> <schema> <nome> <dono>".
+//
+// Schema fica vazio para objetos sem schema (ex: SCHEMA, EXTENSION). Em objetos ligados a uma tabela,
+// como CONSTRAINT, TRIGGER e DEFAULT, o nome começa pelo nome da tabela.
+type Entry struct {
+	ID     int    `json:"id"`
+	Type   string `json:"type"`
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+	line   string
+}
+
+// TOC é a tabela de conteúdo de um backup, na ordem de restauração.
+type TOC []Entry
+
+// ParseList lê a saída do `pg_restore --list`. Comentários e linhas vazias são ignorados.
+func ParseList(r io.Reader) (TOC, error) {
+	toc := make(TOC, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		entry, err := parseEntry(line)
+		if err != nil {
+			return nil, err
+		}
+		toc = append(toc, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return toc, nil
+}
+
+func parseEntry(line string) (Entry, error) {
+	id, rest, ok := strings.Cut(line, ";")
+	if !ok {
+		return Entry{}, fmt.Errorf("linha da TOC inválida: %q", line)
+	}
+	entry := Entry{line: line}
+	var err error
+	if entry.ID, err = strconv.Atoi(strings.TrimSpace(id)); err != nil {
+		return Entry{}, fmt.Errorf("linha da TOC inválida: %q", line)
+	}
+
+	// descarta tableoid e oid
+	fields := strings.Fields(rest)
+	if len(fields) < 3 {
+		return Entry{}, fmt.Errorf("linha da TOC inválida: %q", line)
+	}
+	rest = strings.Join(fields[2:], " ")
+
+	for _, entryType := range entryTypes {
+		if rest == entryType || strings.HasPrefix(rest, entryType+" ") {
+			entry.Type = entryType
+			rest = strings.TrimPrefix(rest, entryType)
+			break
+		}
+	}
+	if entry.Type == "" {
+		return Entry{}, fmt.Errorf("tipo de objeto desconhecido na TOC: %q", line)
+	}
+
+	// objetos sem dono (ex: ENCODING, COMMENT de extensão) terminam com espaço
+	hasOwner := !strings.HasSuffix(line, " ")
+	fields = strings.Fields(rest)
+	if len(fields) > 0 {
+		if fields[0] != "-" {
+			entry.Schema = fields[0]
+		}
+		fields = fields[1:]
+	}
+	if hasOwner && len(fields) > 1 {
+		entry.Owner = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+	entry.Name = strings.Join(fields, " ")
+	return entry, nil
+}
+
+// HasSchema indica se o backup contém o schema ou objetos nele.
+func (toc TOC) HasSchema(name string) bool {
+	for _, entry := range toc {
+		if entry.Schema == name || (entry.Type == TypeSchema && entry.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTable indica se o backup contém a tabela. Com schema vazio, vale a tabela em qualquer schema.
+func (toc TOC) HasTable(schema, name string) bool {
+	for _, entry := range toc {
+		if entry.Type == TypeTable && entry.Name == name && (schema == "" || entry.Schema == schema) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tables retorna as tabelas do backup no formato "schema.tabela".
+func (toc TOC) Tables() []string {
+	tables := make([]string, 0)
+	for _, entry := range toc {
+		if entry.Type == TypeTable {
+			tables = append(tables, entry.Schema+"."+entry.Name)
+		}
+	}
+	return tables
+}
+
+// Table retorna a tabela à qual a entrada pertence (definição, dados ou objetos ligados a ela, como
+// constraints, triggers, defaults e comentários), ou "" se a entrada não pertencer a uma tabela.
+func (e Entry) Table() string {
+	switch e.Type {
+	case TypeTable, TypeTableData:
+		return e.Name
+	case "CONSTRAINT", "CHECK CONSTRAINT", TypeFKConstraint, "TRIGGER", "DEFAULT", "POLICY", "RULE", "ROW SECURITY":
+		table, _, _ := strings.Cut(e.Name, " ")
+		return table
+	case "COMMENT", "ACL", "SECURITY LABEL":
+		if name, ok := strings.CutPrefix(e.Name, "TABLE "); ok {
+			return name
+		}
+		if name, ok := strings.CutPrefix(e.Name, "COLUMN "); ok {
+			table, _, _ := strings.Cut(name, ".")
+			return table
+		}
+	}
+	return ""
+}
+
+// WriteList escreve a lista para `pg_restore -L`, comentando as entradas não selecionadas por keep.
+func (toc TOC) WriteList(w io.Writer, keep func(Entry) bool) error {
+	for _, entry := range toc {
+		line := entry.line
+		if !keep(entry) {
+			line = ";" + line
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitTableName separa "schema.tabela" em schema e tabela. Sem ".", o schema retornado é vazio.
+func SplitTableName(name string) (string, string) {
+	schema, table, ok := strings.Cut(name, ".")
+	if !ok {
+		return "", name
+	}
+	return schema, table
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "fmt"
+
+// FormatBytes formata um tamanho em bytes para leitura humana (ex: 1.5 MB).
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}